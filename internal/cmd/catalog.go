@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/alexshd/projectstarter/internal/generator"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	catalogFile        string
+	catalogGoProxy     string
+	catalogNPMRegistry string
+	catalogWrite       bool
+)
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Maintain the dependency version catalog",
+	Long:  `Maintain the version catalog generated projects read their dependency versions from, see "proj versions".`,
+}
+
+var catalogCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report newer dependency versions available locally",
+	Long: `Compare the version catalog with the releases available in local sources,
+without network access:
+  - Go modules in the module cache, or in a GOPROXY directory (--go-proxy)
+  - npm packages in a local registry mirror (--npm-registry): a directory with
+    a packument per package at <name>/package.json, as in Verdaccio's storage,
+    or the URL of a registry running locally
+
+Elm packages and elm-tooling tools aren't checked. Without --catalog the
+catalog built into proj is checked; --write updates a catalog file in place.`,
+	Example: `  # Check the built-in catalog against the module cache
+  proj catalog check
+
+  # Check against a GOPROXY directory and a Verdaccio mirror, then update
+  proj catalog check --catalog internal/generator/catalog.yaml \
+    --go-proxy file:///srv/goproxy --npm-registry ~/.local/share/verdaccio/storage --write`,
+	Args: cobra.NoArgs,
+	RunE: runCatalogCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(catalogCmd)
+	catalogCmd.AddCommand(catalogCheckCmd)
+
+	catalogCheckCmd.Flags().StringVar(&catalogFile, "catalog", "", "catalog file to check (default: the built-in catalog)")
+	catalogCheckCmd.Flags().StringVar(&catalogGoProxy, "go-proxy", "", "GOPROXY directory or file:// URL to look up Go modules in (default: the module cache)")
+	catalogCheckCmd.Flags().StringVar(&catalogNPMRegistry, "npm-registry", "", "local npm registry directory or URL to look up npm packages in (default: npm packages aren't checked)")
+	catalogCheckCmd.Flags().BoolVar(&catalogWrite, "write", false, "write newer versions to the --catalog file")
+}
+
+func runCatalogCheck(cmd *cobra.Command, args []string) error {
+	if catalogWrite && catalogFile == "" {
+		return fmt.Errorf("--write needs the --catalog file to update")
+	}
+
+	catalog := generator.DefaultCatalog()
+	var data []byte
+	if catalogFile != "" {
+		var err error
+		if data, err = os.ReadFile(catalogFile); err != nil {
+			return fmt.Errorf("failed to read catalog: %w", err)
+		}
+		if catalog, err = generator.ParseCatalog(data); err != nil {
+			return fmt.Errorf("%s: %w", catalogFile, err)
+		}
+	}
+
+	sources := generator.CatalogSources{GoProxy: catalogGoProxy, NPMRegistry: catalogNPMRegistry}
+	if sources.GoProxy == "" {
+		dir, err := generator.ModuleCacheProxy()
+		if err != nil {
+			return fmt.Errorf("failed to find the module cache, pass --go-proxy: %w", err)
+		}
+		sources.GoProxy = dir
+	}
+
+	checks, err := generator.CheckCatalog(catalog, sources)
+	if err != nil {
+		return err
+	}
+
+	updates := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, c := range checks {
+		status := ""
+		switch {
+		case c.Update != "":
+			status = color.GreenString("→ %s", c.Update)
+			updates++
+		case c.Latest == "":
+			status = color.YellowString("not found")
+		default:
+			status = "up to date"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Ecosystem, c.Name, c.Version, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if updates == 0 {
+		color.Green("✅ The catalog is up to date")
+		return nil
+	}
+	if !catalogWrite {
+		color.Cyan("📦 %d newer versions available, run with --write to update the catalog", updates)
+		return nil
+	}
+
+	out, err := generator.UpdateCatalogFile(data, checks)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(catalogFile, out, 0o644); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+	color.Green("📦 Updated %d versions in %s", updates, catalogFile)
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/alexshd/projectstarter/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// userConfig holds the user's defaults for proj, read from config.yaml in
+// the user config directory (~/.config/proj/config.yaml on Linux) or from
+// the file named by $PROJ_CONFIG. Flags override it.
+type userConfig struct {
+	// GoVersion is the default for --go-version
+	GoVersion string `yaml:"go_version"`
+	// Toolchain is the default for --toolchain
+	Toolchain string `yaml:"toolchain"`
+	// Versions override dependency versions of the embedded catalog, in
+	// the format of its catalog.yaml
+	Versions generator.Catalog `yaml:"versions"`
+}
+
+// userConfigPath returns where the user config is read from
+func userConfigPath() (string, error) {
+	if path := os.Getenv("PROJ_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "proj", "config.yaml"), nil
+}
+
+// loadUserConfig reads the user config. A missing file is an empty config.
+func loadUserConfig() (userConfig, error) {
+	var cfg userConfig
+
+	path, err := userConfigPath()
+	if err != nil {
+		// No home directory to look in, so there's no config either
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
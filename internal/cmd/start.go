@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"strings"
 
 	"github.com/alexshd/projectstarter/internal/generator"
 	"github.com/fatih/color"
@@ -30,11 +33,52 @@ var startGoCmd = &cobra.Command{
   proj start go myapp
 
   # Create project with full module path
-  proj start go github.com/user/myapp`,
+  proj start go github.com/user/myapp
+
+  # Layer feature packs on top
+  proj start go myapp --with docker,lint,ci-github
+
+  # JSON logs, level from a -log-level flag
+  proj start go myapp --log-format json --log-level-from flag`,
 	Args: cobra.ExactArgs(1),
 	RunE: runStartGo,
 }
 
+// useMakefile writes a Makefile instead of Taskfile.yml, for every project type
+var useMakefile bool
+
+// logFormat and logLevelFrom set up logging in the main.go of "start go"
+// and "start fullstack"
+var (
+	logFormat    string
+	logLevelFrom string
+)
+
+// goVersion and toolchain set the go and toolchain directives of generated
+// go.mod files, defaulting to the user config
+var (
+	goVersion string
+	toolchain string
+)
+
+// goWith holds the --with feature packs for "start go"
+var goWith []string
+
+// viteElmWith holds the --with feature packs for "start vite-elm"
+var viteElmWith []string
+
+// viteElmApp and viteElmInterop select the Browser.application and the
+// flags and ports templates of "start vite-elm", viteElmLint adds elm-review
+// and viteElmTS generates TypeScript instead of JavaScript
+var viteElmApp, viteElmInterop, viteElmLint, viteElmTS bool
+
+// viteElmDarkMode and viteElmComponents extend the Tailwind theme of
+// "start vite-elm"
+var viteElmDarkMode, viteElmComponents bool
+
+// fullstackWith holds the --with feature packs for "start fullstack"
+var fullstackWith []string
+
 var startViteElmCmd = &cobra.Command{
 	Use:   "vite-elm <project-name>",
 	Short: "Create a new Vite + Elm + Tailwind project",
@@ -46,15 +90,156 @@ var startViteElmCmd = &cobra.Command{
   - Working counter example
   - package.json with dev, build, test scripts`,
 	Example: `  # Create new Vite + Elm project
-  proj start vite-elm myapp`,
+  proj start vite-elm myapp
+
+  # Browser.application with routing, page modules and a 404 page
+  proj start vite-elm myapp --app
+
+  # Browser.element with flags and localStorage and console ports
+  proj start vite-elm myapp --interop
+
+  # With elm-review reporting unused and debug code
+  proj start vite-elm myapp --lint
+
+  # main.ts with typed flags and ports
+  proj start vite-elm myapp --interop --ts
+
+  # Dark mode toggle and card and button component classes
+  proj start vite-elm myapp --dark-mode --components
+
+  # With a CI pipeline
+  proj start vite-elm myapp --with ci-github`,
 	Args: cobra.ExactArgs(1),
 	RunE: runStartViteElm,
 }
 
+var startGoTUICmd = &cobra.Command{
+	Use:   "go-tui <project-name>",
+	Short: "Create a new Go terminal UI project",
+	Long: `Create a new Bubble Tea terminal UI project with:
+  - Model/Update/View counter example (Elm architecture)
+  - Key bindings with a help line
+  - Golden-file test of the rendered view
+  - Plain line mode when stdout is not a terminal
+  - go.mod, README.md, LICENSE (MIT), .gitignore`,
+	Example: `  # Create project with short name
+  proj start go-tui mytui
+
+  # Create project with full module path
+  proj start go-tui github.com/user/mytui`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStartGoTUI,
+}
+
+var startFullstackCmd = &cobra.Command{
+	Use:   "fullstack <project-name>",
+	Short: "Create a new Go + Elm full-stack project",
+	Long: `Create a Go backend and a Vite + Elm + Tailwind frontend in one repo:
+  - cmd/projectname/main.go (HTTP server)
+  - internal/server/ (API handlers and middleware)
+  - web/ (Elm app, Vite proxies /api to the Go server)
+  - web/dist embedded with embed.FS in production builds (-tags prod)
+  - Taskfile.yml running both in dev mode
+  - go.mod, README.md, LICENSE (MIT), .gitignore`,
+	Example: `  # Create project with short name
+  proj start fullstack myapp
+
+  # Create project with full module path
+  proj start fullstack github.com/user/myapp
+
+  # JSON logs, level from MYAPP_LOG_LEVEL
+  proj start fullstack myapp --log-format json --log-level-from env
+
+  # Add metrics, tracing and pprof
+  proj start fullstack myapp --with observability`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStartFullstack,
+}
+
+var startGoWasmCmd = &cobra.Command{
+	Use:   "go-wasm <project-name>",
+	Short: "Create a new Go WebAssembly project",
+	Long: `Create a new GOOS=js GOARCH=wasm project with:
+  - cmd/projectname/main.go (syscall/js counter)
+  - web/index.html loader and wasm_exec.js copied from the local GOROOT
+  - cmd/serve/ (static file server for local testing)
+  - Taskfile.yml with build and serve tasks
+  - go.mod, README.md, LICENSE (MIT), .gitignore`,
+	Example: `  # Create project with short name
+  proj start go-wasm mywasm
+
+  # Create project with full module path
+  proj start go-wasm github.com/user/mywasm`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStartGoWasm,
+}
+
+var startGoWorkspaceCmd = &cobra.Command{
+	Use:   "go-workspace <project-name>",
+	Short: "Create a new multi-module Go workspace",
+	Long: `Create a Go workspace with:
+  - go.work using every module
+  - api/ and worker/ (applications, like "proj start go")
+  - pkg/shared/ (library used by both applications)
+  - replace directives so each application also builds on its own
+  - README.md, LICENSE (MIT), .gitignore
+
+Add more modules later with "proj workspace add".`,
+	Example: `  # Create workspace with short name
+  proj start go-workspace mono
+
+  # Create workspace with full module path
+  proj start go-workspace github.com/user/mono`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStartGoWorkspace,
+}
+
+var startGoDBCmd = &cobra.Command{
+	Use:   "go-db <project-name>",
+	Short: "Create a new database-backed Go project",
+	Long: `Create a Go project like "proj start go", plus:
+  - migrations/ (numbered SQL up/down files, embedded with embed.FS)
+  - internal/db/ (SQLite connection and migration runner)
+  - internal/store/ (repository layer using database/sql)
+  - Tests against in-memory SQLite (pure Go, no cgo or external database)`,
+	Example: `  # Create project with short name
+  proj start go-db myservice
+
+  # Create project with full module path
+  proj start go-db github.com/user/myservice`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStartGoDB,
+}
+
 func init() {
+	startCmd.PersistentFlags().BoolVar(&useMakefile, "makefile", false, "write a Makefile instead of Taskfile.yml")
+	startGoCmd.Flags().StringSliceVar(&goWith, "with", nil, packsUsage("go"))
+	startViteElmCmd.Flags().StringSliceVar(&viteElmWith, "with", nil, packsUsage("vite-elm"))
+	startViteElmCmd.Flags().BoolVar(&viteElmApp, "app", false, "generate a Browser.application with Url.Parser routing instead of the counter")
+	startViteElmCmd.Flags().BoolVar(&viteElmInterop, "interop", false, "generate a Browser.element counter with typed flags and localStorage and console ports")
+	startViteElmCmd.Flags().BoolVar(&viteElmLint, "lint", false, "add an elm-review configuration reporting unused and debug code, run by npm run lint")
+	startViteElmCmd.Flags().BoolVar(&viteElmTS, "ts", false, "generate src/main.ts and vite.config.ts, type-checked against a declaration of Main.elm's flags and ports")
+	startViteElmCmd.Flags().BoolVar(&viteElmDarkMode, "dark-mode", false, "add a class-based dark mode to the theme and a toggle to the Elm app")
+	startViteElmCmd.Flags().BoolVar(&viteElmComponents, "components", false, "add a Tailwind components layer with card and button classes built from the design tokens")
+	startViteElmCmd.MarkFlagsMutuallyExclusive("app", "interop")
+	startFullstackCmd.Flags().StringSliceVar(&fullstackWith, "with", nil, packsUsage("fullstack"))
+	for _, cmd := range []*cobra.Command{startGoCmd, startGoTUICmd, startFullstackCmd, startGoWasmCmd, startGoWorkspaceCmd, startGoDBCmd} {
+		cmd.Flags().StringVar(&goVersion, "go-version", "", "go directive of go.mod, e.g. 1.24 (default: the local Go version)")
+		cmd.Flags().StringVar(&toolchain, "toolchain", "", `toolchain directive of go.mod, e.g. go1.24.2, or "local" for the local Go version`)
+	}
+	for _, cmd := range []*cobra.Command{startGoCmd, startFullstackCmd, startGoWorkspaceCmd, startGoDBCmd} {
+		cmd.Flags().StringVar(&logFormat, "log-format", "tint", "slog handler in main.go: tint, text or json")
+		cmd.Flags().StringVar(&logLevelFrom, "log-level-from", "", "read the log level from a -log-level flag or a NAME_LOG_LEVEL env var: flag or env")
+	}
+
 	rootCmd.AddCommand(startCmd)
 	startCmd.AddCommand(startGoCmd)
 	startCmd.AddCommand(startViteElmCmd)
+	startCmd.AddCommand(startGoTUICmd)
+	startCmd.AddCommand(startFullstackCmd)
+	startCmd.AddCommand(startGoWasmCmd)
+	startCmd.AddCommand(startGoWorkspaceCmd)
+	startCmd.AddCommand(startGoDBCmd)
 }
 
 func runStartGo(cmd *cobra.Command, args []string) error {
@@ -63,7 +248,23 @@ func runStartGo(cmd *cobra.Command, args []string) error {
 	slog.Info("Creating Go project", "name", projectName)
 
 	gen := generator.NewGoGenerator()
-	if err := gen.Generate(projectName); err != nil {
+	if useMakefile {
+		gen.UseMakefile()
+	}
+	if err := withGoVersion(gen); err != nil {
+		return err
+	}
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	if err := gen.WithLogging(logFormat, logLevelFrom); err != nil {
+		return err
+	}
+	if err := gen.WithPacks(goWith...); err != nil {
+		return err
+	}
+	needsTidy, err := checkOffline(gen.Generate(projectName))
+	if err != nil {
 		return fmt.Errorf("failed to generate project: %w", err)
 	}
 
@@ -72,7 +273,7 @@ func runStartGo(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	color.Cyan("🚀 Next steps:")
 	color.Yellow("   cd %s", projectName)
-	color.Yellow("   go mod tidy && go run cmd/%s/main.go", projectName)
+	color.Yellow("   %sgo run cmd/%s/main.go", tidyFirst(needsTidy), projectName)
 	fmt.Println()
 
 	return nil
@@ -84,6 +285,33 @@ func runStartViteElm(cmd *cobra.Command, args []string) error {
 	slog.Info("Creating Vite + Elm + Tailwind project", "name", projectName)
 
 	gen := generator.NewViteElmGenerator()
+	if useMakefile {
+		gen.UseMakefile()
+	}
+	if viteElmApp {
+		gen.UseApp()
+	}
+	if viteElmInterop {
+		gen.UseInterop()
+	}
+	if viteElmLint {
+		gen.UseLint()
+	}
+	if viteElmTS {
+		gen.UseTypeScript()
+	}
+	if viteElmDarkMode {
+		gen.UseDarkMode()
+	}
+	if viteElmComponents {
+		gen.UseComponents()
+	}
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	if err := gen.WithPacks(viteElmWith...); err != nil {
+		return err
+	}
 	if err := gen.Generate(projectName); err != nil {
 		return fmt.Errorf("failed to generate project: %w", err)
 	}
@@ -98,3 +326,251 @@ func runStartViteElm(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runStartGoTUI(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+
+	slog.Info("Creating Go terminal UI project", "name", projectName)
+
+	gen := generator.NewGoTUIGenerator()
+	if useMakefile {
+		gen.UseMakefile()
+	}
+	if err := withGoVersion(gen); err != nil {
+		return err
+	}
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	needsTidy, err := checkOffline(gen.Generate(projectName))
+	if err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	projectDir := filepath.Base(projectName)
+
+	fmt.Println()
+	color.Green("📟 Terminal UI project created successfully!")
+	fmt.Println()
+	color.Cyan("🚀 Next steps:")
+	color.Yellow("   cd %s", projectDir)
+	color.Yellow("   %sgo run ./cmd/%s", tidyFirst(needsTidy), projectDir)
+	fmt.Println()
+
+	return nil
+}
+
+func runStartFullstack(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+
+	slog.Info("Creating Go + Elm full-stack project", "name", projectName)
+
+	gen := generator.NewFullstackGenerator()
+	if useMakefile {
+		gen.UseMakefile()
+	}
+	if err := withGoVersion(gen); err != nil {
+		return err
+	}
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	if err := gen.WithLogging(logFormat, logLevelFrom); err != nil {
+		return err
+	}
+	if err := gen.WithPacks(fullstackWith...); err != nil {
+		return err
+	}
+	needsTidy, err := checkOffline(gen.Generate(projectName))
+	if err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	projectDir := filepath.Base(projectName)
+
+	fmt.Println()
+	color.Green("🧩 Full-stack project created successfully!")
+	fmt.Println()
+	color.Cyan("🚀 Next steps:")
+	color.Yellow("   cd %s", projectDir)
+	color.Yellow("   %stask install && task dev", tidyFirst(needsTidy))
+	fmt.Println()
+
+	return nil
+}
+
+func runStartGoWasm(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+
+	slog.Info("Creating Go WebAssembly project", "name", projectName)
+
+	gen := generator.NewGoWasmGenerator()
+	if useMakefile {
+		gen.UseMakefile()
+	}
+	if err := withGoVersion(gen); err != nil {
+		return err
+	}
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	needsTidy, err := checkOffline(gen.Generate(projectName))
+	if err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	projectDir := filepath.Base(projectName)
+
+	fmt.Println()
+	color.Green("🕸️ WebAssembly project created successfully!")
+	fmt.Println()
+	color.Cyan("🚀 Next steps:")
+	color.Yellow("   cd %s", projectDir)
+	color.Yellow("   %sGOOS=js GOARCH=wasm go build -o web/main.wasm ./cmd/%s", tidyFirst(needsTidy), projectDir)
+	color.Yellow("   go run ./cmd/serve")
+	fmt.Println()
+
+	return nil
+}
+
+func runStartGoWorkspace(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+
+	slog.Info("Creating Go workspace", "name", projectName)
+
+	gen := generator.NewGoWorkspaceGenerator()
+	if useMakefile {
+		gen.UseMakefile()
+	}
+	if err := withGoVersion(gen); err != nil {
+		return err
+	}
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	if err := gen.WithLogging(logFormat, logLevelFrom); err != nil {
+		return err
+	}
+	needsTidy, err := checkOffline(gen.Generate(projectName))
+	if err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	projectDir := filepath.Base(projectName)
+
+	fmt.Println()
+	color.Green("🗂️ Go workspace created successfully!")
+	fmt.Println()
+	color.Cyan("🚀 Next steps:")
+	color.Yellow("   cd %s", projectDir)
+	if needsTidy {
+		color.Yellow("   for dir in $(go list -m -f '{{.Dir}}'); do (cd $dir && go mod tidy); done")
+	}
+	color.Yellow("   go run ./api/cmd/api")
+	color.Yellow("   proj workspace add <module>")
+	fmt.Println()
+
+	return nil
+}
+
+func runStartGoDB(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+
+	slog.Info("Creating database-backed Go project", "name", projectName)
+
+	gen := generator.NewGoDBGenerator()
+	if useMakefile {
+		gen.UseMakefile()
+	}
+	if err := withGoVersion(gen); err != nil {
+		return err
+	}
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	if err := gen.WithLogging(logFormat, logLevelFrom); err != nil {
+		return err
+	}
+	needsTidy, err := checkOffline(gen.Generate(projectName))
+	if err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+
+	projectDir := filepath.Base(projectName)
+
+	fmt.Println()
+	color.Green("🗄️ Database-backed Go project created successfully!")
+	fmt.Println()
+	color.Cyan("🚀 Next steps:")
+	color.Yellow("   cd %s", projectDir)
+	color.Yellow("   %sgo run ./cmd/%s", tidyFirst(needsTidy), projectDir)
+	fmt.Println()
+
+	return nil
+}
+
+// checkOffline turns an OfflineModulesError from generating a Go project
+// into a warning: the project is written, only go.sum is incomplete.
+// needsTidy reports that case, for the next steps to run go mod tidy.
+func checkOffline(err error) (needsTidy bool, _ error) {
+	var offline *generator.OfflineModulesError
+	if !errors.As(err, &offline) {
+		return false, err
+	}
+	slog.Warn("Dependencies missing from the local module cache, run go mod tidy with network access to complete go.sum", "error", err)
+	return true, nil
+}
+
+// tidyFirst prefixes a next step with go mod tidy when go.sum is incomplete
+func tidyFirst(needsTidy bool) string {
+	if needsTidy {
+		return "go mod tidy && "
+	}
+	return ""
+}
+
+// withGoVersion applies --go-version and --toolchain, or their defaults
+// from the user config, to a Go generator
+func withGoVersion(gen interface {
+	WithGoVersion(goVersion, toolchain string) error
+}) error {
+	cfg, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+
+	v, tc := cfg.GoVersion, cfg.Toolchain
+	if goVersion != "" {
+		v = goVersion
+	}
+	if toolchain != "" {
+		tc = toolchain
+	}
+	return gen.WithGoVersion(v, tc)
+}
+
+// withVersions applies the dependency versions overridden in the user config
+func withVersions(gen interface {
+	WithVersions(overrides generator.Catalog) error
+}) error {
+	cfg, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+	if err := gen.WithVersions(cfg.Versions); err != nil {
+		return fmt.Errorf("invalid versions in the proj config: %w", err)
+	}
+	return nil
+}
+
+// packsUsage describes the --with flag for projects of the given kind
+func packsUsage(kind string) string {
+	var lines []string
+	for _, p := range generator.Packs(kind) {
+		lines = append(lines, fmt.Sprintf("\n  %-12s %s", p.Name, p.Description))
+	}
+	if len(lines) == 0 {
+		return "comma-separated feature packs to add (none available yet)"
+	}
+	return "comma-separated feature packs to add:" + strings.Join(lines, "")
+}
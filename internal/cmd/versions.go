@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/alexshd/projectstarter/internal/generator"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "List the dependency versions generated projects use",
+	Long: `List the version catalog: the Go modules, npm packages, Node.js version,
+elm-tooling tools and Elm packages generated projects depend on, and the
+version of each.
+
+Versions can be overridden under "versions:" in the proj config file, in the
+same format as the embedded catalog:
+
+  versions:
+    go:
+      github.com/lmittmann/tint: v1.1.2
+    npm:
+      vite: ^7.1.12`,
+	Args: cobra.NoArgs,
+	RunE: runVersions,
+}
+
+func init() {
+	rootCmd.AddCommand(versionsCmd)
+}
+
+func runVersions(cmd *cobra.Command, args []string) error {
+	cfg, err := loadUserConfig()
+	if err != nil {
+		return err
+	}
+
+	catalog, err := generator.DefaultCatalog().Override(cfg.Versions)
+	if err != nil {
+		return fmt.Errorf("invalid versions in the proj config: %w", err)
+	}
+
+	overridden := map[generator.CatalogEntry]bool{}
+	for _, e := range cfg.Versions.Entries() {
+		overridden[generator.CatalogEntry{Ecosystem: e.Ecosystem, Name: e.Name}] = true
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	ecosystem := ""
+	for _, e := range catalog.Entries() {
+		if e.Ecosystem != ecosystem {
+			if ecosystem != "" {
+				fmt.Fprintln(w)
+			}
+			ecosystem = e.Ecosystem
+			fmt.Fprintln(w, color.CyanString(ecosystem))
+		}
+
+		fmt.Fprintf(w, "  %s\t%s", e.Name, e.Version)
+		if overridden[generator.CatalogEntry{Ecosystem: e.Ecosystem, Name: e.Name}] {
+			fmt.Fprintf(w, "\t%s", color.YellowString("(from config)"))
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
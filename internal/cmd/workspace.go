@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/alexshd/projectstarter/internal/generator"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Manage a Go workspace",
+	Long:  `Manage a multi-module Go workspace created with "proj start go-workspace".`,
+}
+
+var workspaceAddCmd = &cobra.Command{
+	Use:   "add <module-dir>",
+	Short: "Add a module to the workspace in the current directory",
+	Long: `Add a new module to the workspace and register it in go.work.
+
+The module path is derived from the existing modules. Directories under pkg/
+become libraries; anything else becomes an application that depends on every
+library in the workspace.`,
+	Example: `  # Add an application module
+  proj workspace add cli
+
+  # Add a library module
+  proj workspace add pkg/storage`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkspaceAdd,
+}
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspaceAddCmd)
+}
+
+func runWorkspaceAdd(cmd *cobra.Command, args []string) error {
+	moduleDir := args[0]
+
+	slog.Info("Adding module to workspace", "dir", moduleDir)
+
+	gen := generator.NewGoWorkspaceGenerator()
+	if err := withVersions(gen); err != nil {
+		return err
+	}
+	needsTidy, err := checkOffline(gen.AddModule(".", moduleDir))
+	if err != nil {
+		return fmt.Errorf("failed to add module: %w", err)
+	}
+
+	fmt.Println()
+	color.Green("📦 Module %s added to go.work!", moduleDir)
+	fmt.Println()
+	color.Cyan("🚀 Next steps:")
+	color.Yellow("   cd %s && %sgo test ./...", moduleDir, tidyFirst(needsTidy))
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"golang.org/x/mod/module"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed catalog.yaml
+var catalogYAML []byte
+
+// Catalog holds the versions of the third-party dependencies generated
+// projects use, by ecosystem and then by module, package or tool name.
+// Templates read every version from it; the defaults are embedded from
+// catalog.yaml.
+type Catalog struct {
+	// Go maps module paths to versions, like "v1.1.2"
+	Go map[string]string `yaml:"go,omitempty"`
+	// NPM maps package names to package.json ranges, like "^7.1.12"
+	NPM map[string]string `yaml:"npm,omitempty"`
+	// Runtimes maps runtimes to versions, like "22" for Node.js
+	Runtimes map[string]string `yaml:"runtimes,omitempty"`
+	// ElmTooling maps elm-tooling.json tools to versions, like "0.19.1"
+	ElmTooling map[string]string `yaml:"elm-tooling,omitempty"`
+	// Elm maps Elm packages to versions, like "1.0.5"
+	Elm map[string]string `yaml:"elm,omitempty"`
+}
+
+// CatalogEntry is one dependency in a Catalog.
+type CatalogEntry struct {
+	// Ecosystem is "go", "npm", "runtimes", "elm-tooling" or "elm", as in
+	// catalog.yaml
+	Ecosystem string
+	Name      string
+	Version   string
+}
+
+// runtimeVersion matches the versions of runtimes, major or more precise
+var runtimeVersion = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+// elmVersion matches the versions of Elm packages and elm-tooling tools
+var elmVersion = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+// DefaultCatalog returns the catalog embedded in proj.
+func DefaultCatalog() Catalog {
+	c, err := ParseCatalog(catalogYAML)
+	if err != nil {
+		panic("embedded catalog.yaml: " + err.Error())
+	}
+	return c
+}
+
+// ParseCatalog reads a catalog in the format of catalog.yaml, rejecting
+// unknown ecosystems and invalid versions.
+func ParseCatalog(data []byte) (Catalog, error) {
+	var c Catalog
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil {
+		return Catalog{}, fmt.Errorf("failed to parse version catalog: %w", err)
+	}
+	if err := c.validate(); err != nil {
+		return Catalog{}, err
+	}
+	return c, nil
+}
+
+// Override returns the catalog with versions from overrides replacing its
+// own. Only existing entries can be overridden, since templates read no
+// others.
+func (c Catalog) Override(overrides Catalog) (Catalog, error) {
+	if err := overrides.validate(); err != nil {
+		return Catalog{}, err
+	}
+
+	out := Catalog{
+		Go:         maps.Clone(c.Go),
+		NPM:        maps.Clone(c.NPM),
+		Runtimes:   maps.Clone(c.Runtimes),
+		ElmTooling: maps.Clone(c.ElmTooling),
+		Elm:        maps.Clone(c.Elm),
+	}
+	outVersions := out.ecosystems()
+	for i, eco := range overrides.ecosystems() {
+		for _, name := range slices.Sorted(maps.Keys(eco.versions)) {
+			if _, ok := outVersions[i].versions[name]; !ok {
+				return Catalog{}, fmt.Errorf("version catalog has no %s dependency %q to override", eco.name, name)
+			}
+			outVersions[i].versions[name] = eco.versions[name]
+		}
+	}
+	return out, nil
+}
+
+// Entries lists the catalog by ecosystem, in the order of catalog.yaml,
+// then by name.
+func (c Catalog) Entries() []CatalogEntry {
+	var entries []CatalogEntry
+	for _, eco := range c.ecosystems() {
+		for _, name := range slices.Sorted(maps.Keys(eco.versions)) {
+			entries = append(entries, CatalogEntry{Ecosystem: eco.name, Name: name, Version: eco.versions[name]})
+		}
+	}
+	return entries
+}
+
+type catalogEcosystem struct {
+	name     string
+	versions map[string]string
+}
+
+// ecosystems returns the catalog's maps with their names, in the order of
+// catalog.yaml
+func (c Catalog) ecosystems() []catalogEcosystem {
+	return []catalogEcosystem{
+		{"go", c.Go},
+		{"npm", c.NPM},
+		{"runtimes", c.Runtimes},
+		{"elm-tooling", c.ElmTooling},
+		{"elm", c.Elm},
+	}
+}
+
+func (c Catalog) validate() error {
+	for _, e := range c.Entries() {
+		var err error
+		switch e.Ecosystem {
+		case "go":
+			err = module.Check(e.Name, e.Version)
+		case "npm":
+			if e.Version == "" {
+				err = fmt.Errorf("%s: empty version", e.Name)
+			}
+		case "runtimes":
+			if !runtimeVersion.MatchString(e.Version) {
+				err = fmt.Errorf("%s: invalid version %q, expected one like 22 or 22.11", e.Name, e.Version)
+			}
+		default:
+			if !elmVersion.MatchString(e.Version) {
+				err = fmt.Errorf("%s: invalid version %q, expected one like 1.0.0", e.Name, e.Version)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("version catalog: invalid %s dependency: %w", e.Ecosystem, err)
+		}
+	}
+	return nil
+}
+
+// version looks up a dependency templates use. The embedded catalog must
+// have every one, which the tests check.
+func (c Catalog) version(versions map[string]string, ecosystem, name string) string {
+	v, ok := versions[name]
+	if !ok {
+		panic(fmt.Sprintf("version catalog has no %s dependency %q", ecosystem, name))
+	}
+	return v
+}
+
+// goModule returns the version of a Go module, like "v1.1.2"
+func (c Catalog) goModule(path string) string { return c.version(c.Go, "go", path) }
+
+// npm returns the package.json range of an npm package, like "^7.1.12"
+func (c Catalog) npm(name string) string { return c.version(c.NPM, "npm", name) }
+
+// runtime returns the version of a runtime, like "22" for "node"
+func (c Catalog) runtime(name string) string { return c.version(c.Runtimes, "runtimes", name) }
+
+// elmTool returns the version of a tool installed by elm-tooling
+func (c Catalog) elmTool(name string) string { return c.version(c.ElmTooling, "elm-tooling", name) }
+
+// elmPackage returns the version of an Elm package
+func (c Catalog) elmPackage(name string) string { return c.version(c.Elm, "elm", name) }
@@ -0,0 +1,304 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogSources are the local places CheckCatalog looks up releases in,
+// so a check needs no network access.
+type CatalogSources struct {
+	// GoProxy is a directory in the layout of a GOPROXY, or a file:// URL
+	// of one, like the module cache's cache/download directory. Empty
+	// skips Go modules.
+	GoProxy string
+	// NPMRegistry is a directory of npm packuments at <name>/package.json,
+	// like the storage of a Verdaccio mirror, or the http(s) URL of a local
+	// registry. Empty skips npm packages.
+	NPMRegistry string
+}
+
+// CatalogCheck is the result of looking up one catalog entry.
+type CatalogCheck struct {
+	CatalogEntry
+	// Latest is the newest release in the source, empty when the source
+	// doesn't have the dependency
+	Latest string
+	// Update is the version to put in the catalog, empty unless Latest is
+	// newer. npm ranges keep their operator, like "^7.2.0".
+	Update string
+}
+
+// ModuleCacheProxy returns the download directory of the local module cache,
+// which is laid out like a GOPROXY.
+func ModuleCacheProxy() (string, error) {
+	out, err := exec.Command("go", "env", "GOMODCACHE").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run go env GOMODCACHE: %w", err)
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "" {
+		return "", errors.New("go env GOMODCACHE is empty")
+	}
+	return filepath.Join(dir, "cache", "download"), nil
+}
+
+// CheckCatalog looks up the Go modules and npm packages of the catalog in
+// the given sources. Ecosystems without a source aren't checked.
+func CheckCatalog(c Catalog, sources CatalogSources) ([]CatalogCheck, error) {
+	var checks []CatalogCheck
+	for _, e := range c.Entries() {
+		var (
+			check = CatalogCheck{CatalogEntry: e}
+			err   error
+		)
+		switch {
+		case e.Ecosystem == "go" && sources.GoProxy != "":
+			check.Latest, err = latestGoModule(sources.GoProxy, e.Name)
+			if check.Latest != "" && semver.Compare(check.Latest, e.Version) > 0 {
+				check.Update = check.Latest
+			}
+		case e.Ecosystem == "npm" && sources.NPMRegistry != "":
+			check.Latest, err = latestNPMPackage(sources.NPMRegistry, e.Name)
+			check.Update = npmRangeUpdate(e.Version, check.Latest)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s dependency %s: %w", e.Ecosystem, e.Name, err)
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// latestGoModule returns the newest release of a module in a GOPROXY
+// directory, from its @v/list and the versions downloaded to it, or ""
+// when there are none
+func latestGoModule(proxy, path string) (string, error) {
+	dir, err := goProxyDir(proxy)
+	if err != nil {
+		return "", err
+	}
+	escaped, err := module.EscapePath(path)
+	if err != nil {
+		return "", err
+	}
+	versionsDir := filepath.Join(dir, filepath.FromSlash(escaped), "@v")
+
+	var versions []string
+	list, err := os.ReadFile(filepath.Join(versionsDir, "list"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	versions = append(versions, strings.Fields(string(list))...)
+
+	// The module cache has no list for modules only ever downloaded
+	entries, err := os.ReadDir(versionsDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if ext := filepath.Ext(name); ext == ".info" || ext == ".mod" {
+			escapedVersion := strings.TrimSuffix(name, ext)
+			if v, err := module.UnescapeVersion(escapedVersion); err == nil {
+				versions = append(versions, v)
+			}
+		}
+	}
+
+	return latestRelease(versions, ""), nil
+}
+
+// goProxyDir returns the directory of a GOPROXY given as a directory or a
+// file:// URL
+func goProxyDir(proxy string) (string, error) {
+	if !strings.Contains(proxy, "://") {
+		return proxy, nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return "", fmt.Errorf("invalid GOPROXY %q: %w", proxy, err)
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported GOPROXY %q: only local file:// proxies can be checked", proxy)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
+
+// npmPackument is the part of an npm registry's package document the check
+// reads
+type npmPackument struct {
+	DistTags map[string]string          `json:"dist-tags"`
+	Versions map[string]json.RawMessage `json:"versions"`
+}
+
+// latestNPMPackage returns the latest release of an npm package in a
+// registry directory or at a registry URL, or "" when it has none
+func latestNPMPackage(registry, name string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasPrefix(registry, "http://") || strings.HasPrefix(registry, "https://") {
+		data, err = fetchPackument(registry, name)
+	} else {
+		data, err = os.ReadFile(filepath.Join(registry, filepath.FromSlash(name), "package.json"))
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+	}
+	if err != nil || data == nil {
+		return "", err
+	}
+
+	var doc npmPackument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("invalid packument: %w", err)
+	}
+
+	versions := make([]string, 0, len(doc.Versions))
+	for v := range doc.Versions {
+		versions = append(versions, "v"+v)
+	}
+	// The latest tag wins over higher versions, which may be betas or
+	// backports
+	latest := ""
+	if tag := doc.DistTags["latest"]; tag != "" {
+		latest = "v" + tag
+	}
+	return strings.TrimPrefix(latestRelease(versions, latest), "v"), nil
+}
+
+// fetchPackument gets the packument of name from a registry URL, nil when
+// the registry doesn't have it
+func fetchPackument(registry, name string) ([]byte, error) {
+	u := strings.TrimSuffix(registry, "/") + "/" + strings.Replace(name, "/", "%2f", 1)
+	resp, err := http.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// latestRelease returns preferred if it's a valid release, or else the
+// highest release in versions, ignoring pre-releases and +incompatible
+// versions
+func latestRelease(versions []string, preferred string) string {
+	isRelease := func(v string) bool {
+		return semver.IsValid(v) && semver.Prerelease(v) == "" && semver.Build(v) == ""
+	}
+	if isRelease(preferred) {
+		return preferred
+	}
+
+	latest := ""
+	for _, v := range versions {
+		if isRelease(v) && (latest == "" || semver.Compare(v, latest) > 0) {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// npmRangeUpdate returns the range to replace current with so it starts at
+// latest, keeping its operator, or "" if latest isn't newer. Ranges other
+// than a single version with an optional ^, ~ or >= aren't updated.
+func npmRangeUpdate(current, latest string) string {
+	if latest == "" {
+		return ""
+	}
+	base := strings.TrimLeft(current, "^~>=")
+	operator := current[:len(current)-len(base)]
+	if !semver.IsValid("v"+base) || semver.Compare("v"+latest, "v"+base) <= 0 {
+		return ""
+	}
+	return operator + latest
+}
+
+// UpdateCatalogFile rewrites the versions of the checks that have an update
+// in a catalog file's content, leaving its comments and layout as they are.
+func UpdateCatalogFile(data []byte, checks []CatalogCheck) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse version catalog: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("version catalog isn't a mapping of ecosystems")
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	for _, check := range checks {
+		if check.Update == "" {
+			continue
+		}
+		node := catalogValueNode(doc.Content[0], check.Ecosystem, check.Name)
+		if node == nil {
+			return nil, fmt.Errorf("version catalog has no %s dependency %q", check.Ecosystem, check.Name)
+		}
+
+		quote := ""
+		switch node.Style {
+		case yaml.DoubleQuotedStyle:
+			quote = `"`
+		case yaml.SingleQuotedStyle:
+			quote = `'`
+		}
+		old, value := quote+node.Value+quote, quote+check.Update+quote
+
+		line := lines[node.Line-1]
+		start := node.Column - 1
+		if !bytes.HasPrefix(line[start:], []byte(old)) {
+			return nil, fmt.Errorf("can't rewrite %s dependency %q on line %d", check.Ecosystem, check.Name, node.Line)
+		}
+		lines[node.Line-1] = append(append(append([]byte{}, line[:start]...), value...), line[start+len(old):]...)
+	}
+
+	out := bytes.Join(lines, []byte("\n"))
+	if _, err := ParseCatalog(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// catalogValueNode finds the version node of a dependency in the mapping
+// of ecosystems
+func catalogValueNode(root *yaml.Node, ecosystem, name string) *yaml.Node {
+	versions := mappingValue(root, ecosystem)
+	if versions == nil || versions.Kind != yaml.MappingNode {
+		return nil
+	}
+	return mappingValue(versions, name)
+}
+
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes files, relative to dir, creating their directories
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// fakeGoProxy is a file-based GOPROXY: example.com/listed has an @v/list,
+// github.com/User/cached only downloaded versions, like the module cache
+func fakeGoProxy(t *testing.T) string {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"example.com/listed/@v/list":             "v1.0.0\nv1.2.0\nv1.3.0-rc.1\nv2.0.0+incompatible\n",
+		"github.com/!user/cached/@v/v0.9.0.info": `{"Version":"v0.9.0"}`,
+		"github.com/!user/cached/@v/v0.9.0.mod":  "module github.com/User/cached\n",
+		"github.com/!user/cached/@v/v0.10.1.mod": "module github.com/User/cached\n",
+		"example.com/current/@v/list":            "v1.0.0\n",
+	})
+	return dir
+}
+
+// fakeNPMRegistry is a directory of packuments like a Verdaccio storage
+func fakeNPMRegistry(t *testing.T) string {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		// The latest tag is below a beta, which isn't a release
+		"vite/package.json": `{"dist-tags": {"latest": "7.2.1", "beta": "8.0.0-beta.1"},
+			"versions": {"7.1.12": {}, "7.2.1": {}, "8.0.0-beta.1": {}}}`,
+		"@tailwindcss/vite/package.json": `{"dist-tags": {"latest": "4.1.16"}, "versions": {"4.1.16": {}}}`,
+		// No latest tag: the highest release wins
+		"elm-tooling/package.json": `{"versions": {"1.15.0": {}, "1.16.1": {}}}`,
+	})
+	return dir
+}
+
+func TestCheckCatalog(t *testing.T) {
+	catalog := Catalog{
+		Go: map[string]string{
+			"example.com/listed":     "v1.0.0",
+			"github.com/User/cached": "v0.9.0",
+			"example.com/current":    "v1.0.0",
+			"example.com/missing":    "v1.0.0",
+		},
+		NPM: map[string]string{
+			"vite":              "^7.1.12",
+			"@tailwindcss/vite": "^4.1.16",
+			"elm-tooling":       "~1.15.0",
+			"missing":           "^1.0.0",
+		},
+		Elm: map[string]string{"elm/core": "1.0.5"},
+	}
+
+	want := map[string]struct{ latest, update string }{
+		"example.com/listed":     {"v1.2.0", "v1.2.0"},
+		"github.com/User/cached": {"v0.10.1", "v0.10.1"},
+		"example.com/current":    {"v1.0.0", ""},
+		"example.com/missing":    {"", ""},
+		"vite":                   {"7.2.1", "^7.2.1"},
+		"@tailwindcss/vite":      {"4.1.16", ""},
+		"elm-tooling":            {"1.16.1", "~1.16.1"},
+		"missing":                {"", ""},
+	}
+
+	for _, proxy := range []string{fakeGoProxy(t), "file://" + filepath.ToSlash(fakeGoProxy(t))} {
+		checks, err := CheckCatalog(catalog, CatalogSources{GoProxy: proxy, NPMRegistry: fakeNPMRegistry(t)})
+		if err != nil {
+			t.Fatalf("CheckCatalog() failed: %v", err)
+		}
+
+		if len(checks) != len(want) {
+			t.Errorf("expected %d checks, got %d: %v", len(want), len(checks), checks)
+		}
+		for _, c := range checks {
+			w, ok := want[c.Name]
+			if !ok {
+				t.Errorf("unexpected check of %s %s", c.Ecosystem, c.Name)
+				continue
+			}
+			if c.Latest != w.latest || c.Update != w.update {
+				t.Errorf("%s: expected latest %q and update %q, got %q and %q", c.Name, w.latest, w.update, c.Latest, c.Update)
+			}
+		}
+	}
+}
+
+func TestCheckCatalog_Sources(t *testing.T) {
+	catalog := Catalog{
+		Go:  map[string]string{"example.com/listed": "v1.0.0"},
+		NPM: map[string]string{"@tailwindcss/vite": "^4.0.0", "missing": "^1.0.0"},
+	}
+
+	t.Run("without sources nothing is checked", func(t *testing.T) {
+		checks, err := CheckCatalog(catalog, CatalogSources{})
+		if err != nil || len(checks) != 0 {
+			t.Errorf("expected no checks, got %v, %v", checks, err)
+		}
+	})
+
+	t.Run("only file GOPROXY URLs", func(t *testing.T) {
+		_, err := CheckCatalog(catalog, CatalogSources{GoProxy: "https://proxy.golang.org"})
+		if err == nil || !strings.Contains(err.Error(), "only local file:// proxies") {
+			t.Errorf("expected unsupported GOPROXY error, got %v", err)
+		}
+	})
+
+	t.Run("registry URL", func(t *testing.T) {
+		registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != "/@tailwindcss%2fvite" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`{"dist-tags": {"latest": "4.2.0"}, "versions": {"4.2.0": {}}}`))
+		}))
+		defer registry.Close()
+
+		checks, err := CheckCatalog(catalog, CatalogSources{NPMRegistry: registry.URL})
+		if err != nil {
+			t.Fatalf("CheckCatalog() failed: %v", err)
+		}
+		if len(checks) != 2 || checks[0].Update != "^4.2.0" || checks[1].Latest != "" {
+			t.Errorf("unexpected checks: %+v", checks)
+		}
+	})
+}
+
+func TestUpdateCatalogFile(t *testing.T) {
+	data := []byte(`# Go modules
+go:
+  example.com/listed: v1.0.0 # pinned for a reason
+  example.com/other: v1.0.0
+
+npm:
+  "@tailwindcss/vite": "^4.1.16"
+  vite: '^7.1.12'
+`)
+	checks := []CatalogCheck{
+		{CatalogEntry: CatalogEntry{Ecosystem: "go", Name: "example.com/listed", Version: "v1.0.0"}, Latest: "v1.2.0", Update: "v1.2.0"},
+		{CatalogEntry: CatalogEntry{Ecosystem: "go", Name: "example.com/other", Version: "v1.0.0"}, Latest: "v1.0.0"},
+		{CatalogEntry: CatalogEntry{Ecosystem: "npm", Name: "@tailwindcss/vite", Version: "^4.1.16"}, Latest: "4.2.0", Update: "^4.2.0"},
+		{CatalogEntry: CatalogEntry{Ecosystem: "npm", Name: "vite", Version: "^7.1.12"}, Latest: "7.2.1", Update: "^7.2.1"},
+	}
+
+	out, err := UpdateCatalogFile(data, checks)
+	if err != nil {
+		t.Fatalf("UpdateCatalogFile() failed: %v", err)
+	}
+
+	want := `# Go modules
+go:
+  example.com/listed: v1.2.0 # pinned for a reason
+  example.com/other: v1.0.0
+
+npm:
+  "@tailwindcss/vite": "^4.2.0"
+  vite: '^7.2.1'
+`
+	if string(out) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, out)
+	}
+
+	t.Run("unknown dependency", func(t *testing.T) {
+		unknown := []CatalogCheck{{CatalogEntry: CatalogEntry{Ecosystem: "elm", Name: "elm/core"}, Update: "1.0.6"}}
+		if _, err := UpdateCatalogFile(data, unknown); err == nil {
+			t.Error("expected an error for a dependency the file doesn't have")
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	c := DefaultCatalog()
+
+	entries := c.Entries()
+	if len(entries) == 0 {
+		t.Fatal("embedded catalog is empty")
+	}
+	if entries[0].Ecosystem != "go" || entries[len(entries)-1].Ecosystem != "elm" {
+		t.Errorf("Entries() isn't in the order of catalog.yaml: %v", entries)
+	}
+}
+
+// Every catalog entry should be read by some template, or it's stale
+func TestDefaultCatalog_AllEntriesUsed(t *testing.T) {
+	var generated strings.Builder
+	add := func(files map[string]string) {
+		for _, content := range files {
+			generated.WriteString(content)
+		}
+	}
+
+	_, files := NewGoGenerator().layout("github.com/user/myapp")
+	add(files)
+	_, files = NewGoDBGenerator().layout("github.com/user/myapp")
+	add(files)
+	_, files = NewGoTUIGenerator().layout("github.com/user/myapp")
+	add(files)
+	_, files = NewViteElmGenerator().layout("myapp")
+	add(files)
+	elmGen := NewViteElmGenerator()
+	elmGen.UseLint()
+	elmGen.UseTypeScript()
+	_, files = elmGen.layout("myapp")
+	add(files)
+
+	gen := NewFullstackGenerator()
+	_, files = gen.layout("github.com/user/myapp")
+	if err := applyPacks(gen.packTarget("github.com/user/myapp", files), []*pack{observabilityPack}); err != nil {
+		t.Fatalf("applyPacks() failed: %v", err)
+	}
+	add(files)
+
+	for _, e := range DefaultCatalog().Entries() {
+		if !strings.Contains(generated.String(), e.Name) {
+			t.Errorf("%s dependency %s isn't used by any template", e.Ecosystem, e.Name)
+		}
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "valid", yaml: "go:\n  example.com/dep: v1.2.3\nelm:\n  elm/core: 1.0.5\n"},
+		{name: "unknown ecosystem", yaml: "cargo:\n  serde: 1.0.0\n", wantErr: "field cargo not found"},
+		{name: "invalid Go version", yaml: "go:\n  example.com/dep: 1.2.3\n", wantErr: "invalid go dependency"},
+		{name: "invalid Elm version", yaml: "elm:\n  elm/core: ^1.0.5\n", wantErr: "invalid elm dependency"},
+		{name: "empty npm version", yaml: "npm:\n  vite: \"\"\n", wantErr: "invalid npm dependency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCatalog([]byte(tt.yaml))
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ParseCatalog() failed: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("ParseCatalog(): expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCatalog_Override(t *testing.T) {
+	defaults := DefaultCatalog()
+
+	t.Run("replaces versions", func(t *testing.T) {
+		c, err := defaults.Override(Catalog{NPM: map[string]string{"vite": "^8.0.0"}})
+		if err != nil {
+			t.Fatalf("Override() failed: %v", err)
+		}
+		if got := c.npm("vite"); got != "^8.0.0" {
+			t.Errorf("expected vite ^8.0.0, got %s", got)
+		}
+		if got := defaults.npm("vite"); got == "^8.0.0" {
+			t.Error("Override() changed the catalog it was called on")
+		}
+		if got, want := c.npm("tailwindcss"), defaults.npm("tailwindcss"); got != want {
+			t.Errorf("expected tailwindcss to stay at %s, got %s", want, got)
+		}
+	})
+
+	t.Run("rejects unknown dependencies", func(t *testing.T) {
+		_, err := defaults.Override(Catalog{Go: map[string]string{"example.com/unused": "v1.0.0"}})
+		if err == nil || !strings.Contains(err.Error(), `no go dependency "example.com/unused"`) {
+			t.Errorf("expected unknown dependency error, got %v", err)
+		}
+	})
+
+	t.Run("rejects invalid versions", func(t *testing.T) {
+		_, err := defaults.Override(Catalog{Go: map[string]string{tintModule: "latest"}})
+		if err == nil {
+			t.Error("expected invalid version error")
+		}
+	})
+}
+
+func TestWithVersions(t *testing.T) {
+	overrides := Catalog{
+		Go:         map[string]string{tintModule: "v1.9.0", "github.com/prometheus/client_golang": "v1.99.0"},
+		NPM:        map[string]string{"vite": "^8.0.0"},
+		Runtimes:   map[string]string{"node": "24"},
+		ElmTooling: map[string]string{"elm-format": "0.8.8"},
+		Elm:        map[string]string{"elm/core": "1.0.6"},
+	}
+
+	goGen := NewGoGenerator()
+	if err := goGen.WithVersions(overrides); err != nil {
+		t.Fatalf("WithVersions() failed: %v", err)
+	}
+	if goMod := goGen.goModTemplate("myapp"); !strings.Contains(goMod, tintModule+" v1.9.0") {
+		t.Errorf("go.mod doesn't use the overridden tint version:\n%s", goMod)
+	}
+
+	fullstack := NewFullstackGenerator()
+	if err := fullstack.WithVersions(overrides); err != nil {
+		t.Fatalf("WithVersions() failed: %v", err)
+	}
+	_, files := fullstack.layout("myapp")
+	if err := applyPacks(fullstack.packTarget("myapp", files), []*pack{observabilityPack}); err != nil {
+		t.Fatalf("applyPacks() failed: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"go.mod":               "github.com/prometheus/client_golang v1.99.0",
+		"web/package.json":     `"vite": "^8.0.0"`,
+		"web/elm-tooling.json": `"elm-format": "0.8.8"`,
+		"web/elm.json":         `"elm/core": "1.0.6"`,
+	} {
+		if !strings.Contains(files[name], want) {
+			t.Errorf("%s doesn't contain %s:\n%s", name, want, files[name])
+		}
+	}
+
+	elmGen := NewViteElmGenerator()
+	if err := elmGen.WithVersions(overrides); err != nil {
+		t.Fatalf("WithVersions() failed: %v", err)
+	}
+	_, files = elmGen.layout("myapp")
+	packs, err := resolvePacks(builtinPacks, "vite-elm", []string{"ci-github", "devcontainer"})
+	if err != nil {
+		t.Fatalf("resolvePacks() failed: %v", err)
+	}
+	if err := applyPacks(elmGen.packTarget("myapp", files), packs); err != nil {
+		t.Fatalf("applyPacks() failed: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"package.json":                    `"node": ">=24"`,
+		".github/workflows/ci.yml":        "node-version: \"24\"",
+		".devcontainer/devcontainer.json": `"version": "24"`,
+	} {
+		if !strings.Contains(files[name], want) {
+			t.Errorf("%s doesn't use the overridden Node version %s:\n%s", name, want, files[name])
+		}
+	}
+}
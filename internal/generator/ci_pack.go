@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ciProvider renders a CI pipeline for one hosting service.
+type ciProvider struct {
+	name  string
+	title string
+	// path is where the service looks for the pipeline definition
+	path   string
+	render func(job ciJob) string
+}
+
+// ciJob is the provider-independent description of the pipeline: the
+// toolchain to set up and the commands to run with it.
+type ciJob struct {
+	// toolchain is "go" or "node"
+	toolchain string
+	version   string
+	steps     []ciStep
+}
+
+type ciStep struct {
+	name string
+	run  string
+}
+
+var ciProviders = []ciProvider{
+	{
+		name:   "github",
+		title:  "GitHub Actions",
+		path:   ".github/workflows/ci.yml",
+		render: func(job ciJob) string { return actionsWorkflow(job, "ubuntu-latest") },
+	},
+	{
+		name:   "gitlab",
+		title:  "GitLab CI",
+		path:   ".gitlab-ci.yml",
+		render: gitlabPipeline,
+	},
+	{
+		// Forgejo Actions runs GitHub-style workflows; "docker" is the
+		// default label of forgejo-runner
+		name:   "forgejo",
+		title:  "Forgejo Actions",
+		path:   ".forgejo/workflows/ci.yml",
+		render: func(job ciJob) string { return actionsWorkflow(job, "docker") },
+	},
+}
+
+// ciPacks returns one "ci-<provider>" pack per provider. A project gets a
+// single pipeline, so they conflict with each other.
+func ciPacks() []*pack {
+	var names []string
+	for _, provider := range ciProviders {
+		names = append(names, "ci-"+provider.name)
+	}
+
+	packs := make([]*pack, 0, len(ciProviders))
+	for _, provider := range ciProviders {
+		name := "ci-" + provider.name
+		packs = append(packs, &pack{
+			name:        name,
+			description: provider.title + " pipeline running the tests and a build",
+			kinds:       []string{"go", "vite-elm"},
+			conflicts:   slices.DeleteFunc(slices.Clone(names), func(n string) bool { return n == name }),
+			files: func(t *packTarget) map[string]string {
+				return map[string]string{provider.path: provider.render(ciJobFor(t))}
+			},
+			readme: func(t *packTarget) string {
+				return ciReadmeTemplate(provider, ciJobFor(t))
+			},
+		})
+	}
+	return packs
+}
+
+// ciJobFor describes the checks for the target's project type. The Go
+// version is read from the generated go.mod and the Node version is the one
+// package.json declares, so CI builds with what the project asks for.
+func ciJobFor(t *packTarget) ciJob {
+	if t.kind == "vite-elm" {
+		job := ciJob{
+			toolchain: "node",
+			version:   t.catalog().runtime("node"),
+			// Generated projects have no package-lock.json, which npm ci and
+			// lockfile-keyed caches need
+			steps: []ciStep{
+				{name: "Install", run: "npm install"},
+				{name: "Build", run: "npm run build"},
+				{name: "elm-test", run: "npm test"},
+			},
+		}
+		if _, ok := t.files[filepath.Join("review", "elm.json")]; ok {
+			job.steps = append(job.steps, ciStep{name: "elm-review", run: "npm run lint"})
+		}
+		return job
+	}
+
+	version := t.goVersion()
+	if version == "" {
+		version = "1"
+	}
+	return ciJob{
+		toolchain: "go",
+		version:   version,
+		steps: []ciStep{
+			{name: "Vet", run: "go vet ./..."},
+			{name: "Test", run: "go test -race ./..."},
+			{name: "Build", run: "go build ./..."},
+		},
+	}
+}
+
+// actionsWorkflow renders a GitHub Actions style workflow, also used by
+// Forgejo
+func actionsWorkflow(job ciJob, runsOn string) string {
+	var setup string
+	switch job.toolchain {
+	case "node":
+		setup = fmt.Sprintf(`      - uses: actions/setup-node@v4
+        with:
+          node-version: %q
+`, job.version)
+	default:
+		setup = fmt.Sprintf(`      - uses: actions/setup-go@v5
+        with:
+          go-version: %q
+`, job.version)
+	}
+
+	var steps strings.Builder
+	for _, step := range job.steps {
+		fmt.Fprintf(&steps, "      - name: %s\n        run: %s\n", step.name, step.run)
+	}
+
+	return fmt.Sprintf(`name: CI
+
+on:
+  push:
+    branches: [main]
+  pull_request:
+
+jobs:
+  check:
+    runs-on: %s
+    steps:
+      - uses: actions/checkout@v4
+%s%s`, runsOn, setup, steps.String())
+}
+
+func gitlabPipeline(job ciJob) string {
+	image := "golang:" + job.version
+	if job.toolchain == "node" {
+		image = "node:" + job.version
+	}
+
+	var script strings.Builder
+	for _, step := range job.steps {
+		fmt.Fprintf(&script, "    - %s\n", step.run)
+	}
+
+	return fmt.Sprintf(`check:
+  image: %q
+  script:
+%s`, image, script.String())
+}
+
+func ciReadmeTemplate(provider ciProvider, job ciJob) string {
+	var commands []string
+	for _, step := range job.steps {
+		commands = append(commands, "`"+step.run+"`")
+	}
+
+	return fmt.Sprintf(`## CI
+
+`+"`%s`"+` runs %s on %s for every push to main and every pull request.
+`, provider.path, strings.Join(commands, ", "), provider.title)
+}
@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// actionsWorkflowYAML is the part of a GitHub/Forgejo workflow the tests check
+type actionsWorkflowYAML struct {
+	On   map[string]any `yaml:"on"`
+	Jobs map[string]struct {
+		RunsOn string `yaml:"runs-on"`
+		Steps  []struct {
+			Uses string            `yaml:"uses"`
+			With map[string]string `yaml:"with"`
+			Run  string            `yaml:"run"`
+		} `yaml:"steps"`
+	} `yaml:"jobs"`
+}
+
+// gitlabPipelineYAML is the part of .gitlab-ci.yml the tests check
+type gitlabPipelineYAML map[string]struct {
+	Image  string   `yaml:"image"`
+	Script []string `yaml:"script"`
+}
+
+// ciCommands parses a pipeline definition and returns the toolchain version
+// it sets up and the commands it runs
+func ciCommands(t *testing.T, provider, content string) (version string, commands []string) {
+	t.Helper()
+
+	if provider == "gitlab" {
+		var pipeline gitlabPipelineYAML
+		if err := yaml.Unmarshal([]byte(content), &pipeline); err != nil {
+			t.Fatalf("Invalid YAML: %v\n%s", err, content)
+		}
+		job, ok := pipeline["check"]
+		if !ok {
+			t.Fatalf("Pipeline has no check job:\n%s", content)
+		}
+		_, version, _ = strings.Cut(job.Image, ":")
+		return version, job.Script
+	}
+
+	var workflow actionsWorkflowYAML
+	if err := yaml.Unmarshal([]byte(content), &workflow); err != nil {
+		t.Fatalf("Invalid YAML: %v\n%s", err, content)
+	}
+	if _, ok := workflow.On["pull_request"]; !ok {
+		t.Error("Workflow doesn't run on pull requests")
+	}
+	job, ok := workflow.Jobs["check"]
+	if !ok {
+		t.Fatalf("Workflow has no check job:\n%s", content)
+	}
+	if job.RunsOn == "" {
+		t.Error("Workflow job has no runs-on")
+	}
+	for _, step := range job.Steps {
+		if v, ok := step.With["go-version"]; ok {
+			version = v
+		}
+		if v, ok := step.With["node-version"]; ok {
+			version = v
+		}
+		if step.Run != "" {
+			commands = append(commands, step.Run)
+		}
+	}
+	return version, commands
+}
+
+func TestCIPacks(t *testing.T) {
+	paths := map[string]string{
+		"github":  ".github/workflows/ci.yml",
+		"gitlab":  ".gitlab-ci.yml",
+		"forgejo": ".forgejo/workflows/ci.yml",
+	}
+
+	tests := []struct {
+		kind         string
+		wantVersion  string
+		wantCommands []string
+	}{
+		{
+			kind:         "go",
+			wantVersion:  "1.21",
+			wantCommands: []string{"go vet ./...", "go test -race ./...", "go build ./..."},
+		},
+		{
+			kind:         "vite-elm",
+			wantVersion:  "22",
+			wantCommands: []string{"npm install", "npm run build", "npm test"},
+		},
+	}
+
+	for _, tt := range tests {
+		for provider, path := range paths {
+			t.Run(tt.kind+"/"+provider, func(t *testing.T) {
+				target := packedProject(t, tt.kind, "myapp", "ci-"+provider)
+
+				content, ok := target.files[filepath.FromSlash(path)]
+				if !ok {
+					t.Fatalf("Pack didn't add %s", path)
+				}
+
+				version, commands := ciCommands(t, provider, content)
+				if version != tt.wantVersion {
+					t.Errorf("Expected version %q, got %q", tt.wantVersion, version)
+				}
+				if strings.Join(commands, "\n") != strings.Join(tt.wantCommands, "\n") {
+					t.Errorf("Expected commands %q, got %q", tt.wantCommands, commands)
+				}
+
+				if !strings.Contains(target.files["README.md"], "## CI") {
+					t.Error("README doesn't describe the CI pipeline")
+				}
+			})
+		}
+	}
+}
+
+func TestCIPacks_GoVersionFromGoMod(t *testing.T) {
+	target := packedProject(t, "go", "myapp", "ci-github")
+
+	if !strings.Contains(target.files["go.mod"], "\ngo "+target.goVersion()+"\n") {
+		t.Fatalf("goVersion() %q doesn't match go.mod:\n%s", target.goVersion(), target.files["go.mod"])
+	}
+}
+
+func TestCIPacks_NodeVersionFromPackageJSON(t *testing.T) {
+	packageJSON := NewViteElmGenerator().packageJSONTemplate("myapp")
+
+	if !strings.Contains(packageJSON, `"node": ">=22"`) {
+		t.Errorf("package.json doesn't declare Node 22:\n%s", packageJSON)
+	}
+}
+
+func TestCIPacks_SingleProvider(t *testing.T) {
+	_, err := resolvePacks(builtinPacks, "go", []string{"ci-github", "ci-gitlab"})
+	if err == nil || !strings.Contains(err.Error(), "can't be combined") {
+		t.Errorf("Expected conflict error, got: %v", err)
+	}
+}
+
+func TestCIPacks_NoLockfileNeeded(t *testing.T) {
+	// Generated projects don't have a package-lock.json until the first
+	// npm install, so the first CI run can't depend on one
+	paths := map[string]string{
+		"github":  ".github/workflows/ci.yml",
+		"gitlab":  ".gitlab-ci.yml",
+		"forgejo": ".forgejo/workflows/ci.yml",
+	}
+
+	for provider, path := range paths {
+		t.Run(provider, func(t *testing.T) {
+			target := packedProject(t, "vite-elm", "myapp", "ci-"+provider)
+
+			if _, ok := target.files["package-lock.json"]; ok {
+				t.Fatal("Expected no generated package-lock.json")
+			}
+			content := target.files[filepath.FromSlash(path)]
+			for _, needsLockfile := range []string{"npm ci", "cache: npm", "package-lock.json"} {
+				if strings.Contains(content, needsLockfile) {
+					t.Errorf("%s needs a package-lock.json (%q):\n%s", path, needsLockfile, content)
+				}
+			}
+		})
+	}
+}
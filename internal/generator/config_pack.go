@@ -0,0 +1,448 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// configPack adds internal/config: a typed Config loaded from flags, an
+// optional JSON file and environment variables, each overriding the one
+// before, and uses it to set the log level in main.go.
+var configPack = &pack{
+	name:        "config",
+	description: "internal/config from flags, a config file and env vars; configurable log level",
+	kinds:       []string{"go", "fullstack"},
+	files: func(t *packTarget) map[string]string {
+		dir := filepath.Join("internal", "config")
+		return map[string]string{
+			filepath.Join(dir, "config.go"):      configGoTemplate(envPrefix(t.shortName)),
+			filepath.Join(dir, "config_test.go"): configTestTemplate(envPrefix(t.shortName)),
+		}
+	},
+	readme: configReadmeTemplate,
+	main: func(t *packTarget, m *mainGo) {
+		m.addImport("log/slog", "os", t.modulePath+"/internal/config")
+		m.flags = append(m.flags, `	configFlags := config.RegisterFlags(flag.CommandLine)`)
+		m.setup = append(m.setup, `	cfg, err := config.Load(configFlags, os.Getenv)
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	logLevel.Set(cfg.LogLevel)
+	slog.Debug("Loaded configuration", "config", cfg)`)
+	},
+}
+
+// envPrefix is the prefix of a project's environment variables: its name
+// upper-cased, with anything but letters and digits replaced by "_"
+func envPrefix(shortName string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		}
+		return '_'
+	}, shortName) + "_"
+}
+
+func configGoTemplate(prefix string) string {
+	return fmt.Sprintf(`// Package config loads the application's configuration.
+//
+// Every setting has a key, like "log-level", used as the flag name, as the
+// key in the JSON config file and, upper-cased and prefixed, as the
+// environment variable %[1]sLOG_LEVEL. Sources override each other in this
+// order: defaults, flags, the config file, environment variables.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// envPrefix starts the name of every environment variable read by Load.
+const envPrefix = %[1]q
+
+// Config is the application's configuration.
+type Config struct {
+	LogLevel    slog.Level
+	Environment string
+	Timeout     time.Duration
+	// APIKey is a secret: String and LogValue redact it
+	APIKey string
+}
+
+// Default returns the configuration used for settings no source sets.
+func Default() Config {
+	return Config{
+		LogLevel:    slog.LevelInfo,
+		Environment: "development",
+		Timeout:     30 * time.Second,
+	}
+}
+
+// setting describes one field of Config.
+type setting struct {
+	key    string
+	usage  string
+	secret bool
+	set    func(c *Config, value string) error
+	get    func(c Config) string
+}
+
+// settings lists every field of Config, in the order they're printed.
+var settings = []setting{
+	{
+		key:   "log-level",
+		usage: "log level: debug, info, warn or error",
+		set:   func(c *Config, v string) error { return c.LogLevel.UnmarshalText([]byte(v)) },
+		get:   func(c Config) string { return c.LogLevel.String() },
+	},
+	{
+		key:   "environment",
+		usage: "deployment environment: development or production",
+		set:   func(c *Config, v string) error { c.Environment = v; return nil },
+		get:   func(c Config) string { return c.Environment },
+	},
+	{
+		key:   "timeout",
+		usage: "timeout for outgoing requests, e.g. 30s",
+		set: func(c *Config, v string) (err error) {
+			c.Timeout, err = time.ParseDuration(v)
+			return err
+		},
+		get: func(c Config) string { return c.Timeout.String() },
+	},
+	{
+		key:    "api-key",
+		usage:  "API key for the upstream service",
+		secret: true,
+		set:    func(c *Config, v string) error { c.APIKey = v; return nil },
+		get:    func(c Config) string { return c.APIKey },
+	},
+}
+
+// Flags are the configuration flags registered by RegisterFlags.
+type Flags struct {
+	fs     *flag.FlagSet
+	path   *string
+	values map[string]*string
+}
+
+// RegisterFlags defines a flag for every setting, plus -config naming the
+// config file, on fs. Pass the result to Load once fs is parsed.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	defaults := Default()
+	f := &Flags{
+		fs:     fs,
+		path:   fs.String("config", "", "JSON config file (env "+envPrefix+"CONFIG)"),
+		values: map[string]*string{},
+	}
+	for _, s := range settings {
+		f.values[s.key] = fs.String(s.key, defaults.printable(s), s.usage+" (env "+envVar(s.key)+")")
+	}
+	return f
+}
+
+// Load returns the configuration from the defaults, the flags set on the
+// command line, the config file and the environment, looked up with getenv,
+// in increasing order of precedence. The result is validated.
+func Load(flags *Flags, getenv func(string) string) (Config, error) {
+	cfg := Default()
+
+	var errs []error
+	flags.fs.Visit(func(f *flag.Flag) {
+		if _, ok := flags.values[f.Name]; ok {
+			errs = append(errs, cfg.set(f.Name, f.Value.String(), "flag -"+f.Name))
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, err
+	}
+
+	path := *flags.path
+	if env := getenv(envPrefix + "CONFIG"); env != "" {
+		path = env
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, s := range settings {
+		if v, ok := lookupEnv(getenv, envVar(s.key)); ok {
+			errs = append(errs, cfg.set(s.key, v, envVar(s.key)))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// loadFile applies the settings in a JSON object of keys to string values.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %%w", err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse config file %%s: %%w", path, err)
+	}
+
+	var errs []error
+	for key, v := range values {
+		errs = append(errs, c.set(key, v, path))
+	}
+	return errors.Join(errs...)
+}
+
+// set parses value into the setting named key; source names where the
+// value came from for error messages.
+func (c *Config) set(key, value, source string) error {
+	i := slices.IndexFunc(settings, func(s setting) bool { return s.key == key })
+	if i < 0 {
+		return fmt.Errorf("%%s: unknown setting %%q", source, key)
+	}
+	if err := settings[i].set(c, value); err != nil {
+		return fmt.Errorf("%%s: invalid %%s %%q: %%w", source, key, value, err)
+	}
+	return nil
+}
+
+// Validate reports every setting with an invalid value.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Environment != "development" && c.Environment != "production" {
+		errs = append(errs, fmt.Errorf("environment must be development or production, got %%q", c.Environment))
+	}
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("timeout must be positive, got %%s", c.Timeout))
+	}
+	if c.Environment == "production" && c.APIKey == "" {
+		errs = append(errs, errors.New("api-key is required in production"))
+	}
+	return errors.Join(errs...)
+}
+
+// String prints the configuration as key=value pairs, with secrets
+// redacted.
+func (c Config) String() string {
+	pairs := make([]string, len(settings))
+	for i, s := range settings {
+		pairs[i] = s.key + "=" + c.printable(s)
+	}
+	return strings.Join(pairs, " ")
+}
+
+// LogValue logs the configuration as a group, with secrets redacted.
+func (c Config) LogValue() slog.Value {
+	attrs := make([]slog.Attr, len(settings))
+	for i, s := range settings {
+		attrs[i] = slog.String(s.key, c.printable(s))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (c Config) printable(s setting) string {
+	v := s.get(c)
+	if s.secret && v != "" {
+		return "[redacted]"
+	}
+	return v
+}
+
+// envVar is the environment variable of the setting named key.
+func envVar(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// lookupEnv treats empty variables as unset, like most shells' ${VAR:-}.
+func lookupEnv(getenv func(string) string, name string) (string, bool) {
+	v := getenv(name)
+	return v, v != ""
+}
+`, prefix)
+}
+
+func configTestTemplate(prefix string) string {
+	return fmt.Sprintf(`package config
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(file, []byte(`+"`"+`{"log-level": "warn", "timeout": "10s"}`+"`"+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		want    Config
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: Default(),
+		},
+		{
+			name: "flags override defaults",
+			args: []string{"-log-level", "debug", "-timeout", "5s"},
+			want: Config{LogLevel: slog.LevelDebug, Environment: "development", Timeout: 5 * time.Second},
+		},
+		{
+			name: "file overrides flags",
+			args: []string{"-config", file, "-log-level", "debug", "-timeout", "5s"},
+			want: Config{LogLevel: slog.LevelWarn, Environment: "development", Timeout: 10 * time.Second},
+		},
+		{
+			name: "env overrides file",
+			args: []string{"-config", file},
+			env:  map[string]string{%[1]q: "error"},
+			want: Config{LogLevel: slog.LevelError, Environment: "development", Timeout: 10 * time.Second},
+		},
+		{
+			name: "config file from env",
+			env:  map[string]string{%[2]q: file},
+			want: Config{LogLevel: slog.LevelWarn, Environment: "development", Timeout: 10 * time.Second},
+		},
+		{
+			name: "empty env is unset",
+			args: []string{"-log-level", "debug"},
+			env:  map[string]string{%[1]q: ""},
+			want: Config{LogLevel: slog.LevelDebug, Environment: "development", Timeout: 30 * time.Second},
+		},
+		{
+			name:    "invalid value",
+			env:     map[string]string{%[3]q: "soon"},
+			wantErr: "invalid timeout",
+		},
+		{
+			name:    "missing file",
+			args:    []string{"-config", filepath.Join(t.TempDir(), "missing.json")},
+			wantErr: "failed to read config file",
+		},
+		{
+			name:    "fails validation",
+			args:    []string{"-environment", "production"},
+			wantErr: "api-key is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags := RegisterFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse() failed: %%v", err)
+			}
+
+			got, err := Load(flags, func(name string) string { return tt.env[name] })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %%q, got %%v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() failed: %%v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %%v, got %%v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{name: "defaults are valid", modify: func(c *Config) {}},
+		{name: "production with API key", modify: func(c *Config) { c.Environment, c.APIKey = "production", "key" }},
+		{name: "unknown environment", modify: func(c *Config) { c.Environment = "staging" }, wantErr: "environment must be"},
+		{name: "zero timeout", modify: func(c *Config) { c.Timeout = 0 }, wantErr: "timeout must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected no error, got %%v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing %%q, got %%v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStringRedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.APIKey = "s3cret"
+
+	var logged strings.Builder
+	slog.New(slog.NewTextHandler(&logged, nil)).Info("config", "config", cfg)
+
+	for name, out := range map[string]string{"String": cfg.String(), "LogValue": logged.String()} {
+		if strings.Contains(out, "s3cret") {
+			t.Errorf("%%s leaks the API key: %%s", name, out)
+		}
+		if !strings.Contains(out, "api-key=[redacted]") {
+			t.Errorf("%%s doesn't mark the API key as redacted: %%s", name, out)
+		}
+	}
+}
+`, prefix+"LOG_LEVEL", prefix+"CONFIG", prefix+"TIMEOUT")
+}
+
+func configReadmeTemplate(t *packTarget) string {
+	prefix := envPrefix(t.shortName)
+	return fmt.Sprintf(`## Configuration
+
+`+"`internal/config`"+` loads a typed `+"`Config`"+`. Each setting can be set with a
+flag, in a JSON config file, or with an environment variable; environment
+variables win over the file, which wins over flags:
+
+`+"```bash"+`
+go run ./cmd/%[1]s -log-level debug
+echo '{"log-level": "warn"}' > config.json && go run ./cmd/%[1]s -config config.json
+%[2]sLOG_LEVEL=error go run ./cmd/%[1]s
+`+"```"+`
+
+Run with `+"`-h`"+` to list the settings. Invalid values stop the program with every
+problem listed, and the configuration is logged at debug level with secrets
+redacted.
+`, t.shortName, prefix)
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnvPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "myapp", want: "MYAPP_"},
+		{name: "my-app", want: "MY_APP_"},
+		{name: "api.v2", want: "API_V2_"},
+	}
+
+	for _, tt := range tests {
+		if got := envPrefix(tt.name); got != tt.want {
+			t.Errorf("envPrefix(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfigPack(t *testing.T) {
+	files := packedProject(t, "go", "github.com/user/my-app", "config").files
+
+	mainGo := files[filepath.Join("cmd", "my-app", "main.go")]
+	for _, want := range []string{
+		"\t\"github.com/user/my-app/internal/config\"\n",
+		"configFlags := config.RegisterFlags(flag.CommandLine)",
+		"cfg, err := config.Load(configFlags, os.Getenv)",
+		"logLevel.Set(cfg.LogLevel)",
+	} {
+		if !strings.Contains(mainGo, want) {
+			t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+		}
+	}
+
+	configGo := files[filepath.Join("internal", "config", "config.go")]
+	if !strings.Contains(configGo, `const envPrefix = "MY_APP_"`) {
+		t.Errorf("config.go doesn't use the project's env prefix:\n%s", configGo)
+	}
+	if _, ok := files[filepath.Join("internal", "config", "config_test.go")]; !ok {
+		t.Error("config_test.go not added")
+	}
+	if !strings.Contains(files["README.md"], "MY_APP_LOG_LEVEL=error") {
+		t.Error("README doesn't document the environment variables")
+	}
+}
+
+func TestConfigPack_ImportGroups(t *testing.T) {
+	for _, modulePath := range []string{"myapp", "github.com/u/myapp"} {
+		t.Run(modulePath, func(t *testing.T) {
+			mainGo := packedProject(t, "go", modulePath, "config").files[filepath.Join("cmd", "myapp", "main.go")]
+			want := "import (\n\t\"flag\"\n\t\"fmt\"\n\t\"log/slog\"\n\t\"os\"\n\n\t\"github.com/lmittmann/tint\"\n\n\t\"" + modulePath + "/internal/config\"\n)\n"
+			if !strings.Contains(mainGo, want) {
+				t.Errorf("Expected imports %q in:\n%s", want, mainGo)
+			}
+		})
+	}
+}
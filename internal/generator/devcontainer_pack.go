@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// devcontainerPack sets up editors: a dev container with the project's
+// toolchain, .editorconfig, and VS Code settings and extensions that are
+// committed alongside the code.
+var devcontainerPack = &pack{
+	name:        "devcontainer",
+	description: "dev container, .editorconfig and VS Code settings",
+	kinds:       []string{"go", "vite-elm"},
+	files: func(t *packTarget) map[string]string {
+		return map[string]string{
+			filepath.Join(".devcontainer", "devcontainer.json"): devcontainerTemplate(t),
+			filepath.Join(".vscode", "settings.json"):           vscodeSettingsTemplate(t),
+			filepath.Join(".vscode", "extensions.json"):         vscodeExtensionsTemplate(t),
+			".editorconfig": editorconfigTemplate(),
+		}
+	},
+	readme: devcontainerReadmeTemplate,
+}
+
+// vscodeExtensions are the extensions recommended for each project kind
+func vscodeExtensions(kind string) []string {
+	if kind == "vite-elm" {
+		return []string{"Elmtooling.elm-ls-vscode", "bradlc.vscode-tailwindcss", "EditorConfig.EditorConfig"}
+	}
+	return []string{"golang.go", "EditorConfig.EditorConfig"}
+}
+
+// marshalJSON renders v as indented JSON with a trailing newline
+func marshalJSON(v any) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		// Only called with maps of strings, slices and nested maps
+		panic(err)
+	}
+	return b.String()
+}
+
+func devcontainerTemplate(t *packTarget) string {
+	config := map[string]any{
+		"name":  t.shortName,
+		"image": "mcr.microsoft.com/devcontainers/base:bookworm",
+		"customizations": map[string]any{
+			"vscode": map[string]any{"extensions": vscodeExtensions(t.kind)},
+		},
+	}
+
+	if t.kind == "vite-elm" {
+		config["features"] = map[string]any{
+			"ghcr.io/devcontainers/features/node:1": map[string]string{"version": t.catalog().runtime("node")},
+		}
+		config["forwardPorts"] = []int{5173}
+		config["postCreateCommand"] = "npm install"
+	} else {
+		goVersion := t.goVersion()
+		if goVersion == "" {
+			goVersion = "latest"
+		}
+		config["features"] = map[string]any{
+			"ghcr.io/devcontainers/features/go:1": map[string]string{"version": goVersion},
+		}
+		config["postCreateCommand"] = "go mod download"
+	}
+
+	return marshalJSON(config)
+}
+
+func vscodeSettingsTemplate(t *packTarget) string {
+	if t.kind == "vite-elm" {
+		return marshalJSON(map[string]any{
+			"[elm]": map[string]any{"editor.formatOnSave": true},
+			// Tailwind IntelliSense for class names in Elm's class "..."
+			"tailwindCSS.includeLanguages": map[string]string{"elm": "html"},
+			"tailwindCSS.experimental.classRegex": []string{
+				`\bclass[\s(<|]+"([^"]*)"`,
+			},
+			"files.associations": map[string]string{"*.css": "tailwindcss"},
+		})
+	}
+
+	return marshalJSON(map[string]any{
+		"go.useLanguageServer": true,
+		"[go]": map[string]any{
+			"editor.formatOnSave": true,
+			"editor.codeActionsOnSave": map[string]string{
+				"source.organizeImports": "explicit",
+			},
+		},
+		"gopls": map[string]any{"ui.semanticTokens": true},
+	})
+}
+
+func vscodeExtensionsTemplate(t *packTarget) string {
+	return marshalJSON(map[string]any{"recommendations": vscodeExtensions(t.kind)})
+}
+
+func editorconfigTemplate() string {
+	return `# https://editorconfig.org
+root = true
+
+[*]
+charset = utf-8
+end_of_line = lf
+insert_final_newline = true
+trim_trailing_whitespace = true
+indent_style = space
+indent_size = 2
+
+[*.go]
+indent_style = tab
+indent_size = 4
+
+[*.elm]
+indent_size = 4
+
+[{Makefile,*.mk}]
+indent_style = tab
+
+[*.md]
+trim_trailing_whitespace = false
+`
+}
+
+func devcontainerReadmeTemplate(t *packTarget) string {
+	server := "gopls"
+	if t.kind == "vite-elm" {
+		server = "elm-language-server and Tailwind CSS IntelliSense"
+	}
+
+	return fmt.Sprintf(`## Editor setup
+
+Open the project in VS Code and choose "Reopen in Container" to get a dev
+container with the toolchain installed. The recommended extensions set up
+%s. `+"`.vscode/settings.json`"+` and `+"`.vscode/extensions.json`"+`
+are shared through git; other `+"`.vscode/`"+` files stay ignored.
+`, server)
+}
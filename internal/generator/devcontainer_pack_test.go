@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// devcontainerJSON is the part of devcontainer.json the tests check
+type devcontainerJSON struct {
+	Name           string                       `json:"name"`
+	Features       map[string]map[string]string `json:"features"`
+	Customizations struct {
+		VSCode struct {
+			Extensions []string `json:"extensions"`
+		} `json:"vscode"`
+	} `json:"customizations"`
+}
+
+func TestDevcontainerPack(t *testing.T) {
+	tests := []struct {
+		kind        string
+		feature     string
+		version     string
+		extensions  []string
+		settingsKey string
+	}{
+		{
+			kind:        "go",
+			feature:     "ghcr.io/devcontainers/features/go:1",
+			version:     "1.21",
+			extensions:  []string{"golang.go"},
+			settingsKey: "[go]",
+		},
+		{
+			kind:        "vite-elm",
+			feature:     "ghcr.io/devcontainers/features/node:1",
+			version:     "22",
+			extensions:  []string{"Elmtooling.elm-ls-vscode", "bradlc.vscode-tailwindcss"},
+			settingsKey: "tailwindCSS.includeLanguages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			target := packedProject(t, tt.kind, "myapp", "devcontainer")
+
+			var devcontainer devcontainerJSON
+			if err := json.Unmarshal([]byte(target.files[filepath.Join(".devcontainer", "devcontainer.json")]), &devcontainer); err != nil {
+				t.Fatalf("Invalid devcontainer.json: %v", err)
+			}
+			if devcontainer.Name != "myapp" {
+				t.Errorf("Expected name myapp, got %q", devcontainer.Name)
+			}
+			if got := devcontainer.Features[tt.feature]["version"]; got != tt.version {
+				t.Errorf("Expected %s version %q, got %q", tt.feature, tt.version, got)
+			}
+
+			var extensions struct {
+				Recommendations []string `json:"recommendations"`
+			}
+			if err := json.Unmarshal([]byte(target.files[filepath.Join(".vscode", "extensions.json")]), &extensions); err != nil {
+				t.Fatalf("Invalid extensions.json: %v", err)
+			}
+			for _, want := range tt.extensions {
+				if !strings.Contains(strings.Join(extensions.Recommendations, ","), want) {
+					t.Errorf("extensions.json doesn't recommend %s", want)
+				}
+				if !strings.Contains(strings.Join(devcontainer.Customizations.VSCode.Extensions, ","), want) {
+					t.Errorf("devcontainer.json doesn't install %s", want)
+				}
+			}
+
+			var settings map[string]any
+			if err := json.Unmarshal([]byte(target.files[filepath.Join(".vscode", "settings.json")]), &settings); err != nil {
+				t.Fatalf("Invalid settings.json: %v", err)
+			}
+			if _, ok := settings[tt.settingsKey]; !ok {
+				t.Errorf("settings.json has no %q", tt.settingsKey)
+			}
+
+			if !strings.Contains(target.files[".editorconfig"], "root = true") {
+				t.Error(".editorconfig not added")
+			}
+		})
+	}
+}
+
+// gitignored reports whether path matches the .gitignore patterns the
+// generators use: plain paths, "dir/" and "dir/*", with "!" negations
+func gitignored(gitignore, path string) bool {
+	ignored := false
+	for _, line := range strings.Split(gitignore, "\n") {
+		pattern, negate := strings.CutPrefix(strings.TrimSpace(line), "!")
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		dir, _ := filepath.Split(path)
+		matched := pattern == path ||
+			strings.HasSuffix(pattern, "/") && strings.HasPrefix(path, pattern) ||
+			strings.HasSuffix(pattern, "/*") && dir == strings.TrimSuffix(pattern, "*")
+		if matched {
+			ignored = !negate
+		}
+	}
+	return ignored
+}
+
+func TestDevcontainerPack_SettingsNotIgnored(t *testing.T) {
+	for _, kind := range []string{"go", "vite-elm"} {
+		t.Run(kind, func(t *testing.T) {
+			target := packedProject(t, kind, "myapp", "devcontainer")
+			gitignore := target.files[".gitignore"]
+
+			for _, path := range []string{".vscode/settings.json", ".vscode/extensions.json", ".devcontainer/devcontainer.json"} {
+				if gitignored(gitignore, path) {
+					t.Errorf("%s is ignored", path)
+				}
+			}
+			if !gitignored(gitignore, ".vscode/launch.json") {
+				t.Error("Personal .vscode/ files should stay ignored")
+			}
+		})
+	}
+}
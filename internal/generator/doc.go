@@ -10,6 +10,7 @@
 //   - internal/ directory for packages
 //   - go.mod with proper module path
 //   - README.md, LICENSE, .gitignore
+//   - Taskfile.yml with dev, test, build, lint and clean tasks
 //   - Basic passing test
 //
 // ViteElmGenerator creates Vite + Elm + Tailwind projects with:
@@ -17,7 +18,40 @@
 //   - Elm with vite-plugin-elm-watch
 //   - Tailwind CSS v4 with @tailwindcss/vite plugin
 //   - elm-tooling for tool management
-//   - Working counter example with Tailwind styling
+//   - Working counter example styled with Tailwind design tokens
+//   - Unit and fuzz tests of the counter in tests/, run by elm-test-rs
+//   - UseApp() for a Browser.application with routing and page modules
+//   - UseInterop() for a Browser.element with typed flags and ports
+//   - UseLint() for an elm-review configuration and lint script
+//   - UseTypeScript() for main.ts with types of Main.elm's flags and ports
+//   - UseDarkMode() and UseComponents() to extend the Tailwind theme
+//
+// GoTUIGenerator creates Bubble Tea terminal UI projects with:
+//   - Model/Update/View counter mirroring the Elm template
+//   - Key bindings and a golden-file test of the rendered view
+//   - Plain line mode when stdout is not a terminal
+//
+// FullstackGenerator combines both into one repo:
+//   - Go HTTP server with middleware chain at the root
+//   - Vite + Elm app in web/, proxying /api to the server in dev
+//   - web/dist embedded with embed.FS in production builds
+//   - Taskfile.yml that runs both in dev mode
+//
+// GoWasmGenerator creates GOOS=js GOARCH=wasm projects with:
+//   - syscall/js main package and host-testable app logic
+//   - wasm_exec.js copied from the local GOROOT and an index.html loader
+//   - A small static file server and a build task
+//
+// GoWorkspaceGenerator creates multi-module Go workspaces with:
+//   - go.work using every module
+//   - Application modules built from the GoGenerator layout
+//   - Library modules under pkg/, wired in with require and replace
+//   - AddModule to add members to an existing workspace
+//
+// GoDBGenerator extends the GoGenerator layout with:
+//   - Numbered up/down SQL migrations embedded with embed.FS
+//   - A migration runner and a database/sql repository layer
+//   - Tests against in-memory SQLite via a pure-Go driver
 //
 // # Usage
 //
@@ -29,11 +63,37 @@
 //	gen := generator.NewViteElmGenerator()
 //	err := gen.Generate("my-elm-app")
 //
+//	// Create a terminal UI project
+//	gen := generator.NewGoTUIGenerator()
+//	err := gen.Generate("mytui")
+//
+//	// Create a Go + Elm full-stack project
+//	gen := generator.NewFullstackGenerator()
+//	err := gen.Generate("myapp")
+//
+//	// Create a Go WebAssembly project
+//	gen := generator.NewGoWasmGenerator()
+//	err := gen.Generate("mywasm")
+//
+//	// Create a Go workspace and add a module to it
+//	gen := generator.NewGoWorkspaceGenerator()
+//	err := gen.Generate("mono")
+//	err = gen.AddModule("mono", "pkg/storage")
+//
+//	// Create a database-backed Go project
+//	gen := generator.NewGoDBGenerator()
+//	err := gen.Generate("myservice")
+//
 // # Design
 //
 // Each generator follows a consistent pattern:
 //   - NewXGenerator() constructor returns a generator instance
 //   - Generate(projectName) creates the project structure
+//   - UseMakefile() switches the generated Taskfile.yml to a Makefile
+//   - WithVersions(overrides) replaces dependency versions of the embedded
+//     Catalog (catalog.yaml), which every template reads its versions from
+//   - layout(...) returns the files in memory, relative to the project root,
+//     so generators can be combined before anything is written
 //   - Template methods provide file contents
 //   - All generators are thoroughly tested
 package generator
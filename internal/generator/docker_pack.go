@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dockerPack adds a multi-stage Dockerfile for the project's binary, a
+// .dockerignore and a compose.yaml for local runs.
+var dockerPack = &pack{
+	name:        "docker",
+	description: "multi-stage Dockerfile, .dockerignore and compose.yaml",
+	kinds:       []string{"go"},
+	files: func(t *packTarget) map[string]string {
+		return map[string]string{
+			"Dockerfile":   dockerfileTemplate(t),
+			"compose.yaml": composeTemplate(t),
+		}
+	},
+	// .dockerignore copies .gitignore, including the entries packs applied
+	// after this one add
+	finalFiles: func(t *packTarget) map[string]string {
+		return map[string]string{".dockerignore": dockerignoreTemplate(t)}
+	},
+	readme: dockerReadmeTemplate,
+}
+
+func dockerfileTemplate(t *packTarget) string {
+	goVersion := t.goVersion()
+	if goVersion == "" {
+		goVersion = "1"
+	}
+
+	return fmt.Sprintf(`# syntax=docker/dockerfile:1
+
+FROM golang:%[1]s AS build
+WORKDIR /src
+
+# Download modules first so they're cached across source changes
+COPY go.mod go.sum* ./
+RUN go mod download
+
+COPY . .
+RUN CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o /out/%[2]s ./cmd/%[2]s
+
+# distroless/static has CA certificates and a nonroot user; swap in
+# "FROM scratch" for the smallest image if you need neither.
+FROM gcr.io/distroless/static-debian12:nonroot
+COPY --from=build /out/%[2]s /%[2]s
+ENTRYPOINT ["/%[2]s"]
+`, goVersion, t.shortName)
+}
+
+// dockerignoreTemplate keeps everything .gitignore excludes out of the build
+// context, plus the repository and Docker files themselves
+func dockerignoreTemplate(t *packTarget) string {
+	var b strings.Builder
+	b.WriteString(t.files[".gitignore"])
+	if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(`
+# Docker
+.git/
+.dockerignore
+Dockerfile
+compose.yaml
+`)
+	return b.String()
+}
+
+func composeTemplate(t *packTarget) string {
+	return fmt.Sprintf(`services:
+  %[1]s:
+    build: .
+    image: %[1]s:dev
+`, t.shortName)
+}
+
+func dockerReadmeTemplate(t *packTarget) string {
+	return fmt.Sprintf(`## Docker
+
+`+"```bash"+`
+docker build -t %[1]s .
+docker run --rm %[1]s
+
+# or
+docker compose up --build
+`+"```"+`
+`, t.shortName)
+}
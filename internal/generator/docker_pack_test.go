@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDockerPack_BuildPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		modulePath string
+		wantCmd    string
+	}{
+		{name: "short name", modulePath: "myapp", wantCmd: "myapp"},
+		{name: "full module path", modulePath: "github.com/user/myservice", wantCmd: "myservice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := packedProject(t, "go", tt.modulePath, "docker")
+
+			// The build target must be the cmd directory the layout generated
+			mainGo := filepath.ToSlash(filepath.Join("cmd", tt.wantCmd, "main.go"))
+			if _, ok := target.files[filepath.FromSlash(mainGo)]; !ok {
+				t.Fatalf("Layout has no %s", mainGo)
+			}
+
+			dockerfile := target.files["Dockerfile"]
+			wantBuild := "-o /out/" + tt.wantCmd + " ./cmd/" + tt.wantCmd + "\n"
+			if !strings.Contains(dockerfile, wantBuild) {
+				t.Errorf("Dockerfile doesn't build %q:\n%s", wantBuild, dockerfile)
+			}
+			if !strings.Contains(dockerfile, `ENTRYPOINT ["/`+tt.wantCmd+`"]`) {
+				t.Errorf("Dockerfile entrypoint doesn't match the binary:\n%s", dockerfile)
+			}
+			if !strings.Contains(target.files["compose.yaml"], "  "+tt.wantCmd+":\n") {
+				t.Errorf("compose.yaml doesn't define the %s service", tt.wantCmd)
+			}
+		})
+	}
+}
+
+func TestDockerPack_Dockerfile(t *testing.T) {
+	dockerfile := packedProject(t, "go", "myapp", "docker").files["Dockerfile"]
+
+	for _, want := range []string{
+		"FROM golang:1.21 AS build",
+		"CGO_ENABLED=0",
+		"FROM gcr.io/distroless/static-debian12:nonroot",
+		"COPY --from=build /out/myapp /myapp",
+	} {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Dockerfile doesn't contain %q", want)
+		}
+	}
+}
+
+func TestDockerPack_Dockerignore(t *testing.T) {
+	target := packedProject(t, "go", "myapp", "docker")
+	dockerignore := target.files[".dockerignore"]
+
+	if !strings.HasPrefix(dockerignore, NewGoGenerator().gitignoreTemplate()) {
+		t.Error(".dockerignore doesn't start with the .gitignore entries")
+	}
+	for _, want := range []string{".git/", "Dockerfile", "compose.yaml"} {
+		if !strings.Contains(dockerignore, want+"\n") {
+			t.Errorf(".dockerignore doesn't contain %q", want)
+		}
+	}
+}
+
+func TestDockerPack_DockerignoreWithLaterPacks(t *testing.T) {
+	target := packedProject(t, "go", "myapp", "docker", "release")
+
+	if !strings.Contains(target.files[".gitignore"], "dist/\n") {
+		t.Fatal("release pack doesn't ignore dist/")
+	}
+	if !strings.HasPrefix(target.files[".dockerignore"], target.files[".gitignore"]) {
+		t.Errorf(".dockerignore doesn't start with the final .gitignore:\n%s", target.files[".dockerignore"])
+	}
+}
+
+func TestDockerPack_Readme(t *testing.T) {
+	readme := packedProject(t, "go", "myapp", "docker").files["README.md"]
+
+	if !strings.Contains(readme, "docker build -t myapp .") {
+		t.Error("README doesn't explain the Docker build")
+	}
+	if strings.Index(readme, "## Docker") > strings.Index(readme, "## License") {
+		t.Error("Docker section should come before License")
+	}
+}
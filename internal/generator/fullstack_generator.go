@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fullstackAPIAddr is where the Go server listens in development and where
+// the Vite dev server proxies /api requests to.
+const fullstackAPIAddr = "localhost:8080"
+
+type FullstackGenerator struct {
+	goGen  *GoGenerator
+	elmGen *ViteElmGenerator
+	packs  []*pack
+}
+
+func NewFullstackGenerator() *FullstackGenerator {
+	elmGen := NewViteElmGenerator()
+	elmGen.apiProxy = "http://" + fullstackAPIAddr
+
+	return &FullstackGenerator{
+		goGen:  NewGoGenerator(),
+		elmGen: elmGen,
+	}
+}
+
+// WithPacks selects feature packs by name, e.g. "observability". Packs
+// they depend on are added as well.
+func (g *FullstackGenerator) WithPacks(names ...string) error {
+	packs, err := resolvePacks(builtinPacks, "fullstack", names)
+	if err != nil {
+		return err
+	}
+	g.packs = packs
+	return nil
+}
+
+// WithLogging selects the server's slog handler and log level source, see
+// GoGenerator.WithLogging
+func (g *FullstackGenerator) WithLogging(format, levelFrom string) error {
+	return g.goGen.WithLogging(format, levelFrom)
+}
+
+// WithGoVersion sets the go and toolchain directives of go.mod, see
+// GoGenerator.WithGoVersion
+func (g *FullstackGenerator) WithGoVersion(goVersion, toolchain string) error {
+	return g.goGen.WithGoVersion(goVersion, toolchain)
+}
+
+// WithVersions overrides dependency versions of the embedded catalog for
+// both the server and the Elm app, see GoGenerator.WithVersions
+func (g *FullstackGenerator) WithVersions(overrides Catalog) error {
+	if err := g.goGen.WithVersions(overrides); err != nil {
+		return err
+	}
+	return g.elmGen.WithVersions(overrides)
+}
+
+// UseMakefile writes the project tasks to a Makefile instead of Taskfile.yml
+func (g *FullstackGenerator) UseMakefile() {
+	g.goGen.UseMakefile()
+	g.elmGen.UseMakefile()
+}
+
+// Generate creates a new Go + Elm full-stack project with the given name
+func (g *FullstackGenerator) Generate(projectName string) error {
+	// Module path handling is shared with the plain Go generator
+	modulePath, projectDir := g.goGen.parseProjectName(projectName)
+
+	// Check if directory already exists
+	if _, err := os.Stat(projectDir); err == nil {
+		return fmt.Errorf("directory '%s' already exists", projectDir)
+	}
+
+	// Create project structure
+	if err := g.createStructure(projectDir, modulePath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createStructure creates all project files and directories
+func (g *FullstackGenerator) createStructure(projectDir, modulePath string) error {
+	if err := g.goGen.logging.checkPacks(g.packs); err != nil {
+		return err
+	}
+
+	dirs, files := g.layout(modulePath)
+	if err := g.goGen.toolchain.apply(files); err != nil {
+		return err
+	}
+	if err := applyPacks(g.packTarget(modulePath, files), g.packs); err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, dirs, files); err != nil {
+		return err
+	}
+	return g.goGen.tidy(projectDir)
+}
+
+// packTarget describes the project for feature packs, which extend both the
+// server's main.go and internal/server/server.go
+func (g *FullstackGenerator) packTarget(modulePath string, files map[string]string) *packTarget {
+	shortName := filepath.Base(modulePath)
+	return &packTarget{
+		kind:       "fullstack",
+		modulePath: modulePath,
+		shortName:  shortName,
+		files:      files,
+		main:       g.mainGo(shortName, modulePath),
+		mainPath:   filepath.Join("cmd", shortName, "main.go"),
+		server:     g.serverGo(shortName, modulePath),
+		serverPath: filepath.Join("internal", "server", "server.go"),
+		versions:   &g.goGen.versions,
+	}
+}
+
+// layout combines the Go and Vite + Elm layouts: the Go module at the root
+// with an HTTP server instead of the hello world main, the Elm app in web/
+func (g *FullstackGenerator) layout(modulePath string) ([]string, map[string]string) {
+	shortName := filepath.Base(modulePath)
+	cmdDir := filepath.Join("cmd", shortName)
+	serverDir := filepath.Join("internal", "server")
+
+	dirs, files := g.goGen.layout(modulePath)
+
+	// The Elm app lives in web/, minus the files the root already has
+	webDirs, webFiles := g.elmGen.layout(shortName)
+	delete(webFiles, "README.md")
+	delete(webFiles, g.elmGen.runner.filename())
+	webDirs, webFiles = prefixLayout("web", webDirs, webFiles)
+	dirs = append(dirs, webDirs...)
+	for name, content := range webFiles {
+		files[name] = content
+	}
+
+	dirs = append(dirs, serverDir)
+	files[filepath.Join(cmdDir, "main.go")] = g.mainGoTemplate(shortName, modulePath)
+	files[filepath.Join(serverDir, "server.go")] = g.serverTemplate(shortName, modulePath)
+	files[filepath.Join(serverDir, "middleware.go")] = g.middlewareTemplate()
+	files[filepath.Join(serverDir, "server_test.go")] = g.serverTestTemplate(shortName)
+	files[filepath.Join("web", "embed_prod.go")] = g.embedProdTemplate()
+	files[filepath.Join("web", "embed_dev.go")] = g.embedDevTemplate()
+	files["go.mod"] = g.goModTemplate(modulePath)
+	files[g.goGen.runner.filename()] = g.tasksTemplate(shortName)
+	files["README.md"] = g.readmeTemplate(shortName)
+
+	return dirs, files
+}
@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFullstackGenerator_Generate(t *testing.T) {
+	t.Run("creates all required files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		gen := NewFullstackGenerator()
+		if err := gen.Generate("github.com/user/shop"); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		requiredFiles := []string{
+			"cmd/shop/main.go",
+			"internal/server/server.go",
+			"internal/server/middleware.go",
+			"internal/server/server_test.go",
+			"web/embed_prod.go",
+			"web/embed_dev.go",
+			"web/package.json",
+			"web/vite.config.js",
+			"web/src/Main.elm",
+			"web/elm.json",
+			"go.mod",
+			"Taskfile.yml",
+			"README.md",
+			"LICENSE",
+			".gitignore",
+		}
+
+		for _, file := range requiredFiles {
+			fullPath := filepath.Join("shop", file)
+			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+				t.Errorf("Required file not created: %s", file)
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join("shop", "web", "README.md")); err == nil {
+			t.Error("web/ shouldn't have its own README.md")
+		}
+	})
+
+	t.Run("fails when directory already exists", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		if err := os.MkdirAll("existing", 0o755); err != nil {
+			t.Fatalf("Failed to create test directory: %v", err)
+		}
+
+		gen := NewFullstackGenerator()
+		err = gen.Generate("existing")
+		if err == nil {
+			t.Fatal("Expected error when directory exists, got nil")
+		}
+		if !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("Expected 'already exists' error, got: %v", err)
+		}
+	})
+}
+
+func TestFullstackGenerator_Layout(t *testing.T) {
+	gen := NewFullstackGenerator()
+	_, files := gen.layout("github.com/user/shop")
+
+	t.Run("replaces hello world main with the server", func(t *testing.T) {
+		main := files[filepath.Join("cmd", "shop", "main.go")]
+		if !strings.Contains(main, "srv.ListenAndServe()") {
+			t.Error("main.go doesn't start an HTTP server")
+		}
+		if !strings.Contains(main, `"github.com/user/shop/web"`) {
+			t.Error("main.go doesn't import the web package by module path")
+		}
+	})
+
+	t.Run("exits only from main", func(t *testing.T) {
+		main := files[filepath.Join("cmd", "shop", "main.go")]
+		_, run, _ := strings.Cut(main, "\nfunc run(")
+		run, _, _ = strings.Cut(run, "\n}\n")
+		if !strings.Contains(run, "srv.Shutdown(shutdownCtx)") {
+			t.Fatalf("main.go doesn't run the server in run:\n%s", main)
+		}
+		if strings.Contains(run, "os.Exit") {
+			t.Errorf("run exits, skipping its deferred calls:\n%s", run)
+		}
+		if !strings.Contains(main, "if err := run(context.Background(), *addr); err != nil {") {
+			t.Errorf("main doesn't call run:\n%s", main)
+		}
+	})
+
+	t.Run("names the web package after the project", func(t *testing.T) {
+		var pkg map[string]interface{}
+		if err := json.Unmarshal([]byte(files[filepath.Join("web", "package.json")]), &pkg); err != nil {
+			t.Fatalf("web/package.json is invalid JSON: %v", err)
+		}
+		if pkg["name"] != "shop" {
+			t.Errorf("Expected name 'shop', got '%v'", pkg["name"])
+		}
+	})
+
+	t.Run("proxies api in the vite dev server", func(t *testing.T) {
+		config := files[filepath.Join("web", "vite.config.js")]
+		if !strings.Contains(config, "'/api': 'http://"+fullstackAPIAddr+"'") {
+			t.Errorf("vite.config.js doesn't proxy /api to the Go server:\n%s", config)
+		}
+	})
+}
+
+func TestFullstackGenerator_Embed(t *testing.T) {
+	gen := NewFullstackGenerator()
+
+	t.Run("embeds dist in production builds", func(t *testing.T) {
+		content := gen.embedProdTemplate()
+		if !strings.HasPrefix(content, "//go:build prod\n") {
+			t.Error("embed_prod.go isn't limited to the prod build tag")
+		}
+		if !strings.Contains(content, "//go:embed all:dist") {
+			t.Error("embed_prod.go doesn't embed dist")
+		}
+	})
+
+	t.Run("serves nothing in dev builds", func(t *testing.T) {
+		content := gen.embedDevTemplate()
+		if !strings.HasPrefix(content, "//go:build !prod\n") {
+			t.Error("embed_dev.go isn't limited to non-prod builds")
+		}
+		if !strings.Contains(content, "return nil") {
+			t.Error("embed_dev.go doesn't return a nil FS")
+		}
+	})
+}
+
+func TestFullstackGenerator_Server(t *testing.T) {
+	gen := NewFullstackGenerator()
+	content := gen.serverTemplate("shop", "shop")
+
+	t.Run("registers the api route", func(t *testing.T) {
+		if !strings.Contains(content, `"GET /api/hello"`) {
+			t.Error("server.go doesn't register /api/hello")
+		}
+	})
+
+	t.Run("wraps the mux in the middleware chain", func(t *testing.T) {
+		for _, want := range []string{"Chain(mux, middleware...)", "var middleware = []Middleware{Recover, Logger}"} {
+			if !strings.Contains(content, want) {
+				t.Errorf("server.go doesn't contain %q:\n%s", want, content)
+			}
+		}
+	})
+}
+
+func TestFullstackGenerator_Taskfile(t *testing.T) {
+	gen := NewFullstackGenerator()
+	content := gen.tasksTemplate("shop")
+
+	t.Run("runs api and web together in dev", func(t *testing.T) {
+		if !strings.Contains(content, "deps: [dev:api, dev:web]") {
+			t.Error("Taskfile.yml dev task doesn't run both servers")
+		}
+	})
+
+	t.Run("builds with the prod tag", func(t *testing.T) {
+		if !strings.Contains(content, "go build -tags prod -o bin/shop ./cmd/shop") {
+			t.Error("Taskfile.yml doesn't build the binary with -tags prod")
+		}
+	})
+}
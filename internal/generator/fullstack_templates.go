@@ -0,0 +1,314 @@
+package generator
+
+import "fmt"
+
+func (g *FullstackGenerator) mainGoTemplate(projectName, modulePath string) string {
+	return g.mainGo(projectName, modulePath).render()
+}
+
+// mainGo is the HTTP server's main.go, with the same logging setup as the
+// plain Go template. The server runs in run, so main is the only place that
+// exits.
+func (g *FullstackGenerator) mainGo(projectName, modulePath string) *mainGo {
+	m := g.goGen.logging.mainGo(projectName)
+	m.module = modulePath
+	m.addImport("context", "net/http", "os/signal", "syscall", "time", modulePath+"/internal/server", modulePath+"/web")
+	m.decls = append(m.decls, fmt.Sprintf(`func run(ctx context.Context, addr string) error {
+	// web.Dist is nil in dev builds, where Vite serves the Elm app
+	handler := server.New(web.Dist())
+
+	// Ctrl+C or SIGTERM stop the server gracefully: requests in flight
+	// finish before run returns
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Addr: addr, Handler: handler}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	slog.Info("Starting %s", "addr", addr, "embedded", web.Dist() != nil)
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}`, projectName))
+	m.flags = append(m.flags, `	addr := flag.String("addr", ":8080", "address to listen on")`)
+	m.body = []string{`	if err := run(context.Background(), *addr); err != nil {
+		slog.Error("server failed", "error", err)
+		os.Exit(1)
+	}`}
+	return m
+}
+
+func (g *FullstackGenerator) serverTemplate(projectName, modulePath string) string {
+	return g.serverGo(projectName, modulePath).render()
+}
+
+func (g *FullstackGenerator) serverGo(projectName, modulePath string) *serverGo {
+	return &serverGo{projectName: projectName, module: modulePath}
+}
+
+func (g *FullstackGenerator) middlewareTemplate() string {
+	return `package server
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps a handler with extra behaviour.
+type Middleware func(http.Handler) http.Handler
+
+// Chain wraps h with mws. The first middleware is the outermost one, so it
+// sees the request first and the response last.
+func Chain(h http.Handler, mws ...Middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// Recover turns panics in later handlers into 500 responses.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				slog.ErrorContext(r.Context(), "panic serving request", "path", r.URL.Path, "error", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Logger logs one line per request.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		slog.InfoContext(r.Context(), "request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration", time.Since(start),
+		)
+	})
+}
+
+// statusRecorder remembers the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+`
+}
+
+func (g *FullstackGenerator) serverTestTemplate(projectName string) string {
+	return fmt.Sprintf(`package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHello(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(nil).ServeHTTP(rec, httptest.NewRequest("GET", "/api/hello", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %%d", rec.Code)
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %%v", err)
+	}
+	if body["message"] != "Hello from %s!" {
+		t.Errorf("Unexpected message: %%q", body["message"])
+	}
+}
+
+func TestStaticFallsBackToIndex(t *testing.T) {
+	static := fstest.MapFS{
+		"index.html":    {Data: []byte("<div id=\"app\"></div>")},
+		"assets/app.js": {Data: []byte("console.log('app')")},
+	}
+	handler := New(static)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/", want: "id=\"app\""},
+		{path: "/assets/app.js", want: "console.log"},
+		{path: "/some/client/route", want: "id=\"app\""},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %%s: expected status 200, got %%d", tt.path, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("GET %%s: expected body containing %%q, got %%q", tt.path, tt.want, rec.Body.String())
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	panics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	Chain(panics, Recover).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %%d", rec.Code)
+	}
+}
+`, projectName)
+}
+
+func (g *FullstackGenerator) embedProdTemplate() string {
+	return `//go:build prod
+
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+// Dist returns the built Elm app. Production builds (-tags prod) embed it,
+// so run "npm run build" in web/ first.
+func Dist() fs.FS {
+	sub, err := fs.Sub(dist, "dist")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+`
+}
+
+func (g *FullstackGenerator) embedDevTemplate() string {
+	return `//go:build !prod
+
+// Package web holds the Elm frontend and, in production builds, embeds its
+// build output.
+package web
+
+import "io/fs"
+
+// Dist returns nil in dev builds: the Vite dev server serves the Elm app and
+// proxies /api to the Go server.
+func Dist() fs.FS {
+	return nil
+}
+`
+}
+
+func (g *FullstackGenerator) goModTemplate(modulePath string) string {
+	return fmt.Sprintf(`module %s
+
+go 1.22
+%s`, modulePath, g.goGen.logging.goRequire(g.goGen.versions))
+}
+
+func (g *FullstackGenerator) tasksTemplate(projectName string) string {
+	tasks := []task{
+		{name: "install", desc: "Install frontend dependencies", dir: "web", cmds: []string{"npm install"}},
+		{name: "dev", desc: "Run the Go API and the Vite dev server together", aliases: []string{"d"}, deps: []string{"dev:api", "dev:web"}},
+		{name: "dev:api", desc: "Run the Go API on " + fullstackAPIAddr, cmds: []string{"go run ./cmd/" + projectName}},
+		{name: "dev:web", desc: "Run the Vite dev server, proxying /api to the Go API", dir: "web", cmds: []string{"npm run dev"}},
+		{name: "test", desc: "Run all tests", aliases: []string{"t"}, cmds: []string{"go test ./..."}},
+		{
+			name:    "build",
+			desc:    "Build the Elm app and embed it into the Go binary",
+			aliases: []string{"b"},
+			deps:    []string{"build:web"},
+			cmds:    []string{"go build -tags prod -o bin/" + projectName + " ./cmd/" + projectName},
+		},
+		{name: "build:web", desc: "Build the Elm app into web/dist", dir: "web", cmds: []string{"npm run build"}},
+		{name: "lint", desc: "Vet the Go code and check Elm formatting", aliases: []string{"l"}, deps: []string{"lint:web"}, cmds: goLintCmds},
+		{name: "lint:web", desc: "Check Elm formatting", dir: "web", cmds: []string{elmLintCmd}},
+		{name: "clean", desc: "Remove build output", cmds: []string{"rm -rf bin web/dist"}},
+	}
+
+	return g.goGen.runner.render("dev", tasks)
+}
+
+func (g *FullstackGenerator) readmeTemplate(projectName string) string {
+	r := g.goGen.runner
+	return fmt.Sprintf(`# %[1]s
+
+Go + Elm full-stack project created with projectstarter
+
+## Layout
+
+- `+"`cmd/%[1]s/`"+` - HTTP server
+- `+"`internal/server/`"+` - API handlers and middleware
+- `+"`web/`"+` - Vite + Elm + Tailwind frontend
+
+## Setup
+
+`+"```bash"+`
+%[3]s
+`+"```"+`
+
+`+goSumReadme+`
+## Development
+
+`+"```bash"+`
+%[4]s
+`+"```"+`
+
+Open http://localhost:5173. Vite serves the Elm app with hot reload and
+proxies `+"`/api`"+` to the Go server on %[2]s.
+
+## Build
+
+`+"```bash"+`
+%[5]s
+./bin/%[1]s
+`+"```"+`
+
+The production binary is built with `+"`-tags prod`"+` and embeds `+"`web/dist`"+`,
+so it serves both the API and the Elm app on :8080.
+
+## Testing
+
+`+"```bash"+`
+%[6]s
+`+"```"+`
+
+## License
+
+MIT
+`, projectName, fullstackAPIAddr, r.command("install"), r.command("dev"), r.command("build"), r.command("test"))
+}
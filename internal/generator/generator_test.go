@@ -200,7 +200,7 @@ func TestGoGenerator_ParseProjectName(t *testing.T) {
 
 func TestGoGenerator_MainGo(t *testing.T) {
 	gen := NewGoGenerator()
-	content := gen.mainGoTemplate("testapp")
+	content := gen.mainGoTemplate("testapp", "testapp")
 
 	t.Run("is valid package main", func(t *testing.T) {
 		if !strings.Contains(content, "package main") {
@@ -305,8 +305,11 @@ func TestGoGenerator_Readme(t *testing.T) {
 		if !strings.Contains(content, "## Installation") {
 			t.Error("README doesn't have Installation section")
 		}
-		if !strings.Contains(content, "go mod tidy") {
-			t.Error("README doesn't mention go mod tidy")
+		if strings.Contains(content, "```bash\ngo mod tidy\n") {
+			t.Error("README asks to run go mod tidy although proj writes go.sum")
+		}
+		if !strings.Contains(content, goSumReadme) {
+			t.Error("README doesn't explain when go mod tidy is still needed")
 		}
 	})
 
@@ -386,11 +389,19 @@ func TestGoGenerator_Gitignore(t *testing.T) {
 		if !strings.Contains(content, ".idea/") {
 			t.Error(".gitignore doesn't ignore .idea/")
 		}
-		if !strings.Contains(content, ".vscode/") {
+		if !strings.Contains(content, ".vscode/*\n") {
 			t.Error(".gitignore doesn't ignore .vscode/")
 		}
 	})
 
+	t.Run("keeps shared VS Code settings", func(t *testing.T) {
+		for _, want := range []string{"!.vscode/settings.json\n", "!.vscode/extensions.json\n"} {
+			if !strings.Contains(content, want) {
+				t.Errorf(".gitignore doesn't contain %q", want)
+			}
+		}
+	})
+
 	t.Run("ignores OS files", func(t *testing.T) {
 		if !strings.Contains(content, ".DS_Store") {
 			t.Error(".gitignore doesn't ignore .DS_Store")
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// dbMigrations are the numbered migrations of the go-db template, in order.
+// Each one becomes a NNNN_name.up.sql and NNNN_name.down.sql pair.
+var dbMigrations = []struct {
+	name string
+	up   string
+	down string
+}{
+	{
+		name: "create_items",
+		up: `CREATE TABLE items (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       TEXT    NOT NULL,
+    created_at INTEGER NOT NULL
+);
+`,
+		down: "DROP TABLE items;\n",
+	},
+	{
+		name: "unique_item_names",
+		up:   "CREATE UNIQUE INDEX items_name ON items (name);\n",
+		down: "DROP INDEX items_name;\n",
+	},
+}
+
+type GoDBGenerator struct {
+	base *GoGenerator
+}
+
+func NewGoDBGenerator() *GoDBGenerator {
+	return &GoDBGenerator{base: NewGoGenerator()}
+}
+
+// WithLogging selects the slog handler and log level source of main.go, see
+// GoGenerator.WithLogging
+func (g *GoDBGenerator) WithLogging(format, levelFrom string) error {
+	return g.base.WithLogging(format, levelFrom)
+}
+
+// WithGoVersion sets the go and toolchain directives of go.mod, see
+// GoGenerator.WithGoVersion
+func (g *GoDBGenerator) WithGoVersion(goVersion, toolchain string) error {
+	return g.base.WithGoVersion(goVersion, toolchain)
+}
+
+// WithVersions overrides dependency versions of the embedded catalog, see
+// GoGenerator.WithVersions
+func (g *GoDBGenerator) WithVersions(overrides Catalog) error {
+	return g.base.WithVersions(overrides)
+}
+
+// UseMakefile writes the project tasks to a Makefile instead of Taskfile.yml
+func (g *GoDBGenerator) UseMakefile() {
+	g.base.UseMakefile()
+}
+
+// Generate creates a new database-backed Go project with the given name
+func (g *GoDBGenerator) Generate(projectName string) error {
+	// Module path handling is shared with the plain Go generator
+	modulePath, projectDir := g.base.parseProjectName(projectName)
+
+	// Check if directory already exists
+	if _, err := os.Stat(projectDir); err == nil {
+		return fmt.Errorf("directory '%s' already exists", projectDir)
+	}
+
+	// Create project structure
+	if err := g.createStructure(projectDir, modulePath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createStructure creates all project files and directories
+func (g *GoDBGenerator) createStructure(projectDir, modulePath string) error {
+	dirs, files := g.layout(modulePath)
+	if err := g.base.toolchain.apply(files); err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, dirs, files); err != nil {
+		return err
+	}
+	return g.base.tidy(projectDir)
+}
+
+// layout is the Go generator layout plus migrations, the migration runner
+// and a repository layer
+func (g *GoDBGenerator) layout(modulePath string) ([]string, map[string]string) {
+	shortName := filepath.Base(modulePath)
+	dbDir := filepath.Join("internal", "db")
+	storeDir := filepath.Join("internal", "store")
+
+	dirs, files := g.base.layout(modulePath)
+	dirs = append(dirs, "migrations", dbDir, storeDir)
+
+	files[filepath.Join("cmd", shortName, "main.go")] = g.mainGoTemplate(shortName, modulePath)
+	files[filepath.Join("migrations", "migrations.go")] = g.migrationsTemplate()
+	files[filepath.Join(dbDir, "db.go")] = g.dbTemplate()
+	files[filepath.Join(dbDir, "migrate.go")] = g.migrateTemplate()
+	files[filepath.Join(dbDir, "migrate_test.go")] = g.migrateTestTemplate(modulePath)
+	files[filepath.Join(storeDir, "items.go")] = g.itemsTemplate()
+	files[filepath.Join(storeDir, "items_test.go")] = g.itemsTestTemplate(modulePath)
+	files["go.mod"] = g.goModTemplate(modulePath)
+	files["README.md"] = g.readmeTemplate(shortName)
+	files[".gitignore"] = g.gitignoreTemplate()
+
+	for i, m := range dbMigrations {
+		prefix := fmt.Sprintf("%04d_%s", i+1, m.name)
+		files[filepath.Join("migrations", prefix+".up.sql")] = m.up
+		files[filepath.Join("migrations", prefix+".down.sql")] = m.down
+	}
+
+	return dirs, files
+}
@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGoDBGenerator_Generate(t *testing.T) {
+	t.Run("creates all required files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		gen := NewGoDBGenerator()
+		// The files are written even when dependencies aren't cached
+		var offline *OfflineModulesError
+		if err := gen.Generate("github.com/user/inventory"); err != nil && !errors.As(err, &offline) {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		requiredFiles := []string{
+			"cmd/inventory/main.go",
+			"cmd/inventory/main_test.go",
+			"migrations/migrations.go",
+			"migrations/0001_create_items.up.sql",
+			"migrations/0001_create_items.down.sql",
+			"internal/db/db.go",
+			"internal/db/migrate.go",
+			"internal/db/migrate_test.go",
+			"internal/store/items.go",
+			"internal/store/items_test.go",
+			"go.mod",
+			"README.md",
+			"LICENSE",
+			".gitignore",
+		}
+
+		for _, file := range requiredFiles {
+			fullPath := filepath.Join("inventory", file)
+			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+				t.Errorf("Required file not created: %s", file)
+			}
+		}
+	})
+
+	t.Run("fails when directory already exists", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		if err := os.MkdirAll("existing", 0o755); err != nil {
+			t.Fatalf("Failed to create test directory: %v", err)
+		}
+
+		gen := NewGoDBGenerator()
+		err = gen.Generate("existing")
+		if err == nil {
+			t.Fatal("Expected error when directory exists, got nil")
+		}
+		if !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("Expected 'already exists' error, got: %v", err)
+		}
+	})
+}
+
+func TestGoDBGenerator_Migrations(t *testing.T) {
+	gen := NewGoDBGenerator()
+	_, files := gen.layout("inventory")
+
+	migrationName := regexp.MustCompile(`^(\d{4})_[a-z_]+\.(up|down)\.sql$`)
+
+	ups, downs := map[string]bool{}, map[string]bool{}
+	for path := range files {
+		dir, name := filepath.Split(path)
+		if filepath.Clean(dir) != "migrations" || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		m := migrationName.FindStringSubmatch(name)
+		if m == nil {
+			t.Errorf("Migration %s isn't named NNNN_name.(up|down).sql", name)
+			continue
+		}
+		base := strings.TrimSuffix(name, "."+m[2]+".sql")
+		if m[2] == "up" {
+			ups[base] = true
+		} else {
+			downs[base] = true
+		}
+	}
+
+	t.Run("numbers migrations from 0001", func(t *testing.T) {
+		if len(ups) != len(dbMigrations) {
+			t.Fatalf("Expected %d migrations, got %d", len(dbMigrations), len(ups))
+		}
+		if !ups["0001_create_items"] {
+			t.Error("First migration isn't 0001_create_items")
+		}
+	})
+
+	t.Run("pairs every up with a down", func(t *testing.T) {
+		for base := range ups {
+			if !downs[base] {
+				t.Errorf("Migration %s has no down file", base)
+			}
+		}
+		for base := range downs {
+			if !ups[base] {
+				t.Errorf("Migration %s has no up file", base)
+			}
+		}
+	})
+
+	t.Run("embeds the sql files", func(t *testing.T) {
+		content := files[filepath.Join("migrations", "migrations.go")]
+		if !strings.Contains(content, "//go:embed *.sql") {
+			t.Error("migrations.go doesn't embed the SQL files")
+		}
+		if !strings.Contains(content, "embed.FS") {
+			t.Error("migrations.go doesn't expose an embed.FS")
+		}
+	})
+}
+
+func TestGoDBGenerator_MainGo(t *testing.T) {
+	gen := NewGoDBGenerator()
+	content := gen.mainGoTemplate("inventory", "github.com/user/inventory")
+
+	t.Run("migrates on startup", func(t *testing.T) {
+		if !strings.Contains(content, "db.Migrate(ctx, database, migrations.FS)") {
+			t.Error("main.go doesn't run the embedded migrations")
+		}
+	})
+
+	t.Run("imports packages by module path", func(t *testing.T) {
+		for _, pkg := range []string{"internal/db", "internal/store", "migrations"} {
+			if !strings.Contains(content, `"github.com/user/inventory/`+pkg+`"`) {
+				t.Errorf("main.go doesn't import %s", pkg)
+			}
+		}
+	})
+
+	t.Run("keeps the slog setup", func(t *testing.T) {
+		if !strings.Contains(content, "slog.SetDefault") {
+			t.Error("main.go doesn't configure the default logger")
+		}
+		if !strings.Contains(content, `NoColor:    !isTerminal(os.Stderr) || os.Getenv("NO_COLOR") != "",`) {
+			t.Errorf("main.go doesn't use the Go generator's logging setup:\n%s", content)
+		}
+	})
+}
+
+func TestGoDBGenerator_Store(t *testing.T) {
+	gen := NewGoDBGenerator()
+	content := gen.itemsTemplate()
+
+	t.Run("uses database/sql", func(t *testing.T) {
+		if !strings.Contains(content, `"database/sql"`) {
+			t.Error("items.go doesn't use database/sql")
+		}
+	})
+
+	t.Run("uses placeholders", func(t *testing.T) {
+		if !strings.Contains(content, "WHERE id = ?") {
+			t.Error("items.go doesn't use query placeholders")
+		}
+	})
+}
+
+func TestGoDBGenerator_GoMod(t *testing.T) {
+	gen := NewGoDBGenerator()
+	content := gen.goModTemplate("github.com/user/inventory")
+
+	if want := gen.base.goModFor("github.com/user/inventory", "1.23"); !strings.HasPrefix(content, want) {
+		t.Errorf("go.mod doesn't start with the Go generator's go.mod %q:\n%s", want, content)
+	}
+
+	if !strings.Contains(content, "modernc.org/sqlite") {
+		t.Error("go.mod doesn't require the pure-Go SQLite driver")
+	}
+	if !strings.Contains(content, "github.com/lmittmann/tint") {
+		t.Error("go.mod doesn't require tint")
+	}
+}
+
+func TestGoDBGenerator_Gitignore(t *testing.T) {
+	gen := NewGoDBGenerator()
+	content := gen.gitignoreTemplate()
+
+	if !strings.Contains(content, "*.db\n") {
+		t.Error(".gitignore doesn't ignore SQLite databases")
+	}
+	if !strings.Contains(content, "bin/") {
+		t.Error(".gitignore lost the Go defaults")
+	}
+}
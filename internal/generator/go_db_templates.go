@@ -0,0 +1,684 @@
+package generator
+
+import "fmt"
+
+func (g *GoDBGenerator) mainGoTemplate(projectName, modulePath string) string {
+	return g.mainGo(projectName, modulePath).render()
+}
+
+// mainGo is the Go generator's main.go, migrating the database and listing
+// its items in run
+func (g *GoDBGenerator) mainGo(projectName, modulePath string) *mainGo {
+	m := g.base.logging.mainGo(projectName)
+	m.module = modulePath
+	m.addImport("context", "fmt", modulePath+"/internal/db", modulePath+"/internal/store", modulePath+"/migrations")
+	m.decls = append(m.decls, fmt.Sprintf(`func run(ctx context.Context, dsn string) error {
+	database, err := db.Open(dsn)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := db.Migrate(ctx, database, migrations.FS); err != nil {
+		return err
+	}
+
+	items, err := store.NewItems(database).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Starting %[1]s", "db", dsn, "items", len(items))
+	fmt.Println("Hello from %[1]s!")
+	return nil
+}`, projectName))
+	m.flags = append(m.flags, fmt.Sprintf(`	dsn := flag.String("db", "%s.db", "SQLite database file")`, projectName))
+	m.body = []string{`	if err := run(context.Background(), *dsn); err != nil {
+		slog.Error("failed", "error", err)
+		os.Exit(1)
+	}`}
+	return m
+}
+
+func (g *GoDBGenerator) migrationsTemplate() string {
+	return `// Package migrations embeds the SQL schema migrations.
+//
+// Migrations are numbered pairs of files: NNNN_name.up.sql applies a change
+// and NNNN_name.down.sql reverts it. They run in version order.
+package migrations
+
+import "embed"
+
+// FS holds the migration files.
+//
+//go:embed *.sql
+var FS embed.FS
+`
+}
+
+func (g *GoDBGenerator) dbTemplate() string {
+	return `// Package db opens the database and applies schema migrations.
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite" // pure-Go SQLite driver, registered as "sqlite"
+)
+
+// Open opens the SQLite database at dsn, for example "app.db" or ":memory:".
+func Open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	// SQLite allows a single writer, and every connection to ":memory:" is
+	// a separate database, so share one connection.
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	return db, nil
+}
+`
+}
+
+func (g *GoDBGenerator) migrateTemplate() string {
+	return `package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is one numbered schema change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// LoadMigrations reads NNNN_name.up.sql and NNNN_name.down.sql pairs from
+// fsys, sorted by version. Every migration needs both files.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var base, direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			base, direction = strings.TrimSuffix(name, ".up.sql"), "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			base, direction = strings.TrimSuffix(name, ".down.sql"), "down"
+		default:
+			continue
+		}
+
+		number, label, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(number)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_name.%s.sql", name, direction)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		}
+		if m.Name != label {
+			return nil, fmt.Errorf("migration %s: version %d is already used by %s", name, version, m)
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %s: needs both an up and a down file", m)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrate applies every migration in fsys that hasn't been applied yet.
+func Migrate(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return err
+	}
+	return Up(ctx, db, migrations)
+}
+
+// Up applies the pending migrations in version order. Each migration runs in
+// its own transaction together with its schema_migrations record.
+func Up(ctx context.Context, db *sql.DB, migrations []Migration) error {
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if err := apply(ctx, db, m.Up, "INSERT INTO schema_migrations (version) VALUES (?)", m.Version); err != nil {
+			return fmt.Errorf("migration %s up: %w", m, err)
+		}
+	}
+	return nil
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func Down(ctx context.Context, db *sql.DB, migrations []Migration, steps int) error {
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+		m := migrations[i]
+		if !applied[m.Version] {
+			continue
+		}
+		if err := apply(ctx, db, m.Down, "DELETE FROM schema_migrations WHERE version = ?", m.Version); err != nil {
+			return fmt.Errorf("migration %s down: %w", m, err)
+		}
+		steps--
+	}
+	return nil
+}
+
+// appliedVersions creates the schema_migrations table if needed and returns
+// the versions recorded in it.
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	const create = "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)"
+	if _, err := db.ExecContext(ctx, create); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("read schema_migrations: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// apply runs script and the schema_migrations statement in one transaction.
+func apply(ctx context.Context, db *sql.DB, script, record string, version int) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+`
+}
+
+func (g *GoDBGenerator) migrateTestTemplate(modulePath string) string {
+	return fmt.Sprintf(`package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"%s/migrations"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open() failed: %%v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE name = ?", name).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to query sqlite_master: %%v", err)
+	}
+	return count > 0
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	ms, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("LoadMigrations() failed: %%v", err)
+	}
+	if len(ms) == 0 {
+		t.Fatal("No embedded migrations")
+	}
+
+	if err := Up(ctx, db, ms); err != nil {
+		t.Fatalf("Up() failed: %%v", err)
+	}
+	if !tableExists(t, db, "items") {
+		t.Error("items table missing after Up()")
+	}
+
+	// Applying again is a no-op
+	if err := Up(ctx, db, ms); err != nil {
+		t.Fatalf("second Up() failed: %%v", err)
+	}
+
+	// Every down migration must revert its up migration
+	if err := Down(ctx, db, ms, len(ms)); err != nil {
+		t.Fatalf("Down() failed: %%v", err)
+	}
+	if tableExists(t, db, "items") {
+		t.Error("items table still exists after reverting every migration")
+	}
+
+	if err := Up(ctx, db, ms); err != nil {
+		t.Fatalf("Up() after Down() failed: %%v", err)
+	}
+}
+
+func TestDown_RevertsNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	ms := []Migration{
+		{Version: 1, Name: "a", Up: "CREATE TABLE a (id INTEGER)", Down: "DROP TABLE a"},
+		{Version: 2, Name: "b", Up: "CREATE TABLE b (id INTEGER)", Down: "DROP TABLE b"},
+	}
+	if err := Up(ctx, db, ms); err != nil {
+		t.Fatalf("Up() failed: %%v", err)
+	}
+
+	if err := Down(ctx, db, ms, 1); err != nil {
+		t.Fatalf("Down() failed: %%v", err)
+	}
+	if !tableExists(t, db, "a") || tableExists(t, db, "b") {
+		t.Error("Down(1) should revert only the newest migration")
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("sorts by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0010_later.up.sql":     {Data: []byte("SELECT 10")},
+			"0010_later.down.sql":   {Data: []byte("SELECT -10")},
+			"0002_earlier.up.sql":   {Data: []byte("SELECT 2")},
+			"0002_earlier.down.sql": {Data: []byte("SELECT -2")},
+			"README.md":             {Data: []byte("ignored")},
+		}
+
+		ms, err := LoadMigrations(fsys)
+		if err != nil {
+			t.Fatalf("LoadMigrations() failed: %%v", err)
+		}
+		if len(ms) != 2 || ms[0].Version != 2 || ms[1].Version != 10 {
+			t.Errorf("Unexpected migrations: %%v", ms)
+		}
+	})
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "missing down file",
+			fsys: fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1")}},
+			want: "needs both",
+		},
+		{
+			name: "unnumbered file",
+			fsys: fstest.MapFS{"create.up.sql": {Data: []byte("SELECT 1")}},
+			want: "must look like",
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"0001_a.up.sql": {Data: []byte("SELECT 1")},
+				"0001_b.up.sql": {Data: []byte("SELECT 1")},
+			},
+			want: "already used",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %%q, got: %%v", tt.want, err)
+			}
+		})
+	}
+}
+`, modulePath)
+}
+
+func (g *GoDBGenerator) itemsTemplate() string {
+	return `// Package store is the repository layer: it maps database rows to Go types.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound is returned when a requested row doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// Item is a row of the items table.
+type Item struct {
+	ID        int64
+	Name      string
+	CreatedAt time.Time
+}
+
+// Items reads and writes items.
+type Items struct {
+	db *sql.DB
+}
+
+func NewItems(db *sql.DB) *Items {
+	return &Items{db: db}
+}
+
+// Create inserts a new item and returns it with its ID.
+func (s *Items) Create(ctx context.Context, name string) (Item, error) {
+	item := Item{Name: name, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+
+	res, err := s.db.ExecContext(ctx,
+		"INSERT INTO items (name, created_at) VALUES (?, ?)",
+		item.Name, item.CreatedAt.Unix(),
+	)
+	if err != nil {
+		return Item{}, fmt.Errorf("create item: %w", err)
+	}
+
+	item.ID, err = res.LastInsertId()
+	if err != nil {
+		return Item{}, fmt.Errorf("create item: %w", err)
+	}
+	return item, nil
+}
+
+// Get returns the item with the given ID.
+func (s *Items) Get(ctx context.Context, id int64) (Item, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT id, name, created_at FROM items WHERE id = ?", id)
+
+	item, err := scanItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Item{}, ErrNotFound
+	}
+	if err != nil {
+		return Item{}, fmt.Errorf("get item %d: %w", id, err)
+	}
+	return item, nil
+}
+
+// List returns all items, oldest first.
+func (s *Items) List(ctx context.Context) ([]Item, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, name, created_at FROM items ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("list items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list items: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Delete removes the item with the given ID.
+func (s *Items) Delete(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM items WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("delete item %d: %w", id, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete item %d: %w", id, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// scanItem reads an item from a *sql.Row or *sql.Rows.
+func scanItem(row interface{ Scan(...any) error }) (Item, error) {
+	var item Item
+	var createdAt int64
+	if err := row.Scan(&item.ID, &item.Name, &createdAt); err != nil {
+		return Item{}, err
+	}
+	item.CreatedAt = time.Unix(createdAt, 0).UTC()
+	return item, nil
+}
+`
+}
+
+func (g *GoDBGenerator) itemsTestTemplate(modulePath string) string {
+	return fmt.Sprintf(`package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"%s/internal/db"
+	"%[1]s/migrations"
+)
+
+// newTestItems returns an Items store on a fresh, migrated in-memory database.
+func newTestItems(t *testing.T) *Items {
+	t.Helper()
+	database, err := db.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open() failed: %%v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	if err := db.Migrate(context.Background(), database, migrations.FS); err != nil {
+		t.Fatalf("Migrate() failed: %%v", err)
+	}
+	return NewItems(database)
+}
+
+func TestItems(t *testing.T) {
+	ctx := context.Background()
+	items := newTestItems(t)
+
+	created, err := items.Create(ctx, "first")
+	if err != nil {
+		t.Fatalf("Create() failed: %%v", err)
+	}
+
+	got, err := items.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %%v", err)
+	}
+	if got.ID != created.ID || got.Name != created.Name || !got.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("Get() = %%+v, want %%+v", got, created)
+	}
+
+	if _, err := items.Create(ctx, "second"); err != nil {
+		t.Fatalf("Create() failed: %%v", err)
+	}
+	all, err := items.List(ctx)
+	if err != nil {
+		t.Fatalf("List() failed: %%v", err)
+	}
+	if len(all) != 2 || all[0].Name != "first" || all[1].Name != "second" {
+		t.Errorf("Unexpected List() result: %%+v", all)
+	}
+
+	if err := items.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete() failed: %%v", err)
+	}
+	if _, err := items.Get(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after Delete(), got: %%v", err)
+	}
+}
+
+func TestItems_UniqueNames(t *testing.T) {
+	ctx := context.Background()
+	items := newTestItems(t)
+
+	if _, err := items.Create(ctx, "same"); err != nil {
+		t.Fatalf("Create() failed: %%v", err)
+	}
+	if _, err := items.Create(ctx, "same"); err == nil {
+		t.Error("Expected an error for a duplicate name")
+	}
+}
+
+func TestItems_NotFound(t *testing.T) {
+	ctx := context.Background()
+	items := newTestItems(t)
+
+	if _, err := items.Get(ctx, 42); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(): expected ErrNotFound, got: %%v", err)
+	}
+	if err := items.Delete(ctx, 42); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete(): expected ErrNotFound, got: %%v", err)
+	}
+}
+`, modulePath)
+}
+
+// goModTemplate is the Go generator's go.mod plus the SQLite driver, which
+// needs go 1.23
+func (g *GoDBGenerator) goModTemplate(modulePath string) string {
+	return g.base.goModFor(modulePath, "1.23") + fmt.Sprintf(`
+require modernc.org/sqlite %s
+`, g.base.versions.goModule("modernc.org/sqlite"))
+}
+
+func (g *GoDBGenerator) gitignoreTemplate() string {
+	return g.base.gitignoreTemplate() + `
+# SQLite databases
+*.db
+*.db-journal
+*.db-wal
+*.db-shm
+`
+}
+
+func (g *GoDBGenerator) readmeTemplate(projectName string) string {
+	return fmt.Sprintf(`# %[1]s
+
+Database-backed Go project created with projectstarter
+
+## Installation
+
+`+goSumReadme+`
+## Usage
+
+`+"```bash"+`
+go run ./cmd/%[1]s -db %[1]s.db
+`+"```"+`
+
+Pending migrations are applied on startup.
+
+## Layout
+
+- `+"`migrations/`"+` - Numbered SQL migrations, embedded with `+"`embed.FS`"+`
+- `+"`internal/db/`"+` - Opens SQLite and runs the migrations
+- `+"`internal/store/`"+` - Repository layer using `+"`database/sql`"+`
+
+## Migrations
+
+Add a pair of files with the next number:
+
+`+"```"+`
+migrations/0003_add_item_price.up.sql
+migrations/0003_add_item_price.down.sql
+`+"```"+`
+
+The up file applies the change, the down file reverts it. Applied versions are
+recorded in the `+"`schema_migrations`"+` table; each migration runs in its own
+transaction.
+
+## Testing
+
+`+"```bash"+`
+go test ./...
+`+"```"+`
+
+Tests use [modernc.org/sqlite](https://pkg.go.dev/modernc.org/sqlite), a pure-Go
+SQLite driver, with in-memory databases, so they need neither cgo nor an
+external database.
+
+## License
+
+MIT
+`, projectName)
+}
@@ -7,10 +7,79 @@ import (
 	"strings"
 )
 
-type GoGenerator struct{}
+type GoGenerator struct {
+	// packs are the feature packs layered on top of the project
+	packs []*pack
+	// runner writes the project's dev, test, build, lint and clean tasks
+	runner taskRunner
+	// logging is how main.go sets up slog
+	logging logging
+	// toolchain sets the go and toolchain directives of go.mod
+	toolchain goToolchain
+	// tidy completes go.mod and writes go.sum in a written module
+	tidy func(dir string) error
+	// versions are the dependency versions the templates use
+	versions Catalog
+}
 
 func NewGoGenerator() *GoGenerator {
-	return &GoGenerator{}
+	return &GoGenerator{
+		toolchain: goToolchain{local: localGoVersion},
+		tidy:      tidyOffline,
+		versions:  DefaultCatalog(),
+	}
+}
+
+// WithPacks selects feature packs by name, e.g. "docker" or "lint".
+// Packs they depend on are added as well.
+func (g *GoGenerator) WithPacks(names ...string) error {
+	packs, err := resolvePacks(builtinPacks, "go", names)
+	if err != nil {
+		return err
+	}
+	g.packs = packs
+	return nil
+}
+
+// WithLogging selects the slog handler of main.go, "tint", "text" or
+// "json", and where the log level comes from: "flag", "env", or "" for a
+// fixed Info level.
+func (g *GoGenerator) WithLogging(format, levelFrom string) error {
+	l, err := newLogging(format, levelFrom)
+	if err != nil {
+		return err
+	}
+	g.logging = l
+	return nil
+}
+
+// WithGoVersion sets the go directive of go.mod, like "1.24", instead of
+// the version of the local toolchain, and an optional toolchain directive,
+// like "go1.24.2" or "local". Templates needing a newer Go keep their
+// version.
+func (g *GoGenerator) WithGoVersion(goVersion, toolchain string) error {
+	tc, err := newGoToolchain(goVersion, toolchain)
+	if err != nil {
+		return err
+	}
+	g.toolchain = tc
+	return nil
+}
+
+// WithVersions overrides dependency versions of the embedded catalog, see
+// Catalog.Override
+func (g *GoGenerator) WithVersions(overrides Catalog) error {
+	versions, err := DefaultCatalog().Override(overrides)
+	if err != nil {
+		return err
+	}
+	g.versions = versions
+	return nil
+}
+
+// UseMakefile writes the project tasks to a Makefile instead of Taskfile.yml
+func (g *GoGenerator) UseMakefile() {
+	g.runner.makefile = true
 }
 
 // Generate creates a new Go project with the given name
@@ -50,37 +119,60 @@ func (g *GoGenerator) parseProjectName(name string) (modulePath, projectDir stri
 
 // createStructure creates all project files and directories
 func (g *GoGenerator) createStructure(projectDir, modulePath string) error {
-	// Extract short name from path for use in templates
-	shortName := filepath.Base(modulePath)
+	if err := g.logging.checkPacks(g.packs); err != nil {
+		return err
+	}
 
-	// Create directories
-	dirs := []string{
-		projectDir,
-		filepath.Join(projectDir, "cmd", shortName),
-		filepath.Join(projectDir, "internal"),
+	dirs, files := g.layout(modulePath)
+	if err := g.toolchain.apply(files); err != nil {
+		return err
 	}
 
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
-		}
+	if err := applyPacks(g.packTarget(modulePath, files), g.packs); err != nil {
+		return err
 	}
 
-	// Create files
-	files := map[string]string{
-		filepath.Join(projectDir, "cmd", shortName, "main.go"):      g.mainGoTemplate(shortName),
-		filepath.Join(projectDir, "cmd", shortName, "main_test.go"): g.mainTestTemplate(shortName),
-		filepath.Join(projectDir, "go.mod"):                         g.goModTemplate(modulePath),
-		filepath.Join(projectDir, "README.md"):                      g.readmeTemplate(shortName, modulePath),
-		filepath.Join(projectDir, "LICENSE"):                        g.licenseTemplate(),
-		filepath.Join(projectDir, ".gitignore"):                     g.gitignoreTemplate(),
+	if err := writeProject(projectDir, dirs, files); err != nil {
+		return err
 	}
+	return g.tidy(projectDir)
+}
 
-	for path, content := range files {
-		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
-			return fmt.Errorf("failed to create file %s: %w", path, err)
-		}
+// packTarget returns the target feature packs are applied to, for the files
+// of layout(modulePath)
+func (g *GoGenerator) packTarget(modulePath string, files map[string]string) *packTarget {
+	shortName := filepath.Base(modulePath)
+	return &packTarget{
+		kind:       "go",
+		modulePath: modulePath,
+		shortName:  shortName,
+		files:      files,
+		main:       g.mainGo(shortName, modulePath),
+		mainPath:   filepath.Join("cmd", shortName, "main.go"),
+		versions:   &g.versions,
 	}
+}
 
-	return nil
+// layout returns the directories and files of a Go project, relative to the
+// project root
+func (g *GoGenerator) layout(modulePath string) ([]string, map[string]string) {
+	// Extract short name from path for use in templates
+	shortName := filepath.Base(modulePath)
+
+	dirs := []string{
+		filepath.Join("cmd", shortName),
+		"internal",
+	}
+
+	files := map[string]string{
+		filepath.Join("cmd", shortName, "main.go"):      g.mainGoTemplate(shortName, modulePath),
+		filepath.Join("cmd", shortName, "main_test.go"): g.mainTestTemplate(shortName),
+		"go.mod":     g.goModTemplate(modulePath),
+		"README.md":  g.readmeTemplate(shortName, modulePath),
+		"LICENSE":    g.licenseTemplate(),
+		".gitignore": g.gitignoreTemplate(),
+	}
+	files[g.runner.filename()] = g.runner.render("dev", g.tasks(shortName))
+
+	return dirs, files
 }
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type GoTUIGenerator struct {
+	base *GoGenerator
+}
+
+func NewGoTUIGenerator() *GoTUIGenerator {
+	return &GoTUIGenerator{base: NewGoGenerator()}
+}
+
+// WithGoVersion sets the go and toolchain directives of go.mod, see
+// GoGenerator.WithGoVersion
+func (g *GoTUIGenerator) WithGoVersion(goVersion, toolchain string) error {
+	return g.base.WithGoVersion(goVersion, toolchain)
+}
+
+// WithVersions overrides dependency versions of the embedded catalog, see
+// GoGenerator.WithVersions
+func (g *GoTUIGenerator) WithVersions(overrides Catalog) error {
+	return g.base.WithVersions(overrides)
+}
+
+// UseMakefile writes the project tasks to a Makefile instead of Taskfile.yml
+func (g *GoTUIGenerator) UseMakefile() {
+	g.base.UseMakefile()
+}
+
+// Generate creates a new terminal UI project with the given name
+func (g *GoTUIGenerator) Generate(projectName string) error {
+	// Module path handling is shared with the plain Go generator
+	modulePath, projectDir := g.base.parseProjectName(projectName)
+
+	// Check if directory already exists
+	if _, err := os.Stat(projectDir); err == nil {
+		return fmt.Errorf("directory '%s' already exists", projectDir)
+	}
+
+	// Create project structure
+	if err := g.createStructure(projectDir, modulePath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createStructure creates all project files and directories
+func (g *GoTUIGenerator) createStructure(projectDir, modulePath string) error {
+	dirs, files := g.layout(modulePath)
+	if err := g.base.toolchain.apply(files); err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, dirs, files); err != nil {
+		return err
+	}
+	return g.base.tidy(projectDir)
+}
+
+// layout returns the directories and files of a terminal UI project, relative
+// to the project root
+func (g *GoTUIGenerator) layout(modulePath string) ([]string, map[string]string) {
+	// Extract short name from path for use in templates
+	shortName := filepath.Base(modulePath)
+	tuiDir := filepath.Join("internal", "tui")
+
+	dirs := []string{
+		filepath.Join("cmd", shortName),
+		filepath.Join(tuiDir, "testdata"),
+	}
+
+	files := map[string]string{
+		filepath.Join("cmd", shortName, "main.go"): g.mainGoTemplate(shortName, modulePath),
+		filepath.Join(tuiDir, "model.go"):          g.modelTemplate(),
+		filepath.Join(tuiDir, "keys.go"):           g.keysTemplate(),
+		filepath.Join(tuiDir, "plain.go"):          g.plainTemplate(),
+		filepath.Join(tuiDir, "model_test.go"):     g.modelTestTemplate(shortName),
+		"go.mod":                                   g.goModTemplate(modulePath),
+		"README.md":                                g.readmeTemplate(shortName),
+		"LICENSE":                                  g.base.licenseTemplate(),
+		".gitignore":                               g.base.gitignoreTemplate(),
+	}
+
+	files[g.base.runner.filename()] = g.base.runner.render("dev", g.base.tasks(shortName))
+
+	// Golden files hold the expected view for each scenario in model_test.go
+	for _, golden := range tuiGoldenCases {
+		path := filepath.Join(tuiDir, "testdata", golden.name+".golden")
+		files[path] = g.viewGolden(shortName, golden.count)
+	}
+
+	return dirs, files
+}
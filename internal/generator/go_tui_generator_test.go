@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoTUIGenerator_Generate(t *testing.T) {
+	t.Run("creates all required files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		gen := NewGoTUIGenerator()
+		// The files are written even when dependencies aren't cached
+		var offline *OfflineModulesError
+		if err := gen.Generate("github.com/user/mytui"); err != nil && !errors.As(err, &offline) {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		requiredFiles := []string{
+			"cmd/mytui/main.go",
+			"internal/tui/model.go",
+			"internal/tui/keys.go",
+			"internal/tui/plain.go",
+			"internal/tui/model_test.go",
+			"internal/tui/testdata/initial.golden",
+			"go.mod",
+			"README.md",
+			"LICENSE",
+			".gitignore",
+		}
+
+		for _, file := range requiredFiles {
+			fullPath := filepath.Join("mytui", file)
+			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+				t.Errorf("Required file not created: %s", file)
+			}
+		}
+	})
+
+	t.Run("fails when directory already exists", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		if err := os.MkdirAll("existing", 0o755); err != nil {
+			t.Fatalf("Failed to create test directory: %v", err)
+		}
+
+		gen := NewGoTUIGenerator()
+		err = gen.Generate("existing")
+		if err == nil {
+			t.Fatal("Expected error when directory exists, got nil")
+		}
+		if !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("Expected 'already exists' error, got: %v", err)
+		}
+	})
+}
+
+func TestGoTUIGenerator_MainGo(t *testing.T) {
+	gen := NewGoTUIGenerator()
+	content := gen.mainGoTemplate("mytui", "github.com/user/mytui")
+
+	t.Run("imports the tui package by module path", func(t *testing.T) {
+		if !strings.Contains(content, `"github.com/user/mytui/internal/tui"`) {
+			t.Error("main.go doesn't import internal/tui with the module path")
+		}
+	})
+
+	t.Run("runs bubble tea on a terminal", func(t *testing.T) {
+		if !strings.Contains(content, "tea.NewProgram(model).Run()") {
+			t.Error("main.go doesn't start a Bubble Tea program")
+		}
+	})
+
+	t.Run("falls back to plain mode without a terminal", func(t *testing.T) {
+		if !strings.Contains(content, "!isTerminal(os.Stdout)") {
+			t.Error("main.go doesn't check whether stdout is a terminal")
+		}
+		if !strings.Contains(content, "tui.RunPlain(model, os.Stdin, os.Stdout)") {
+			t.Error("main.go doesn't fall back to the plain runner")
+		}
+	})
+}
+
+func TestGoTUIGenerator_Model(t *testing.T) {
+	gen := NewGoTUIGenerator()
+	content := gen.modelTemplate()
+
+	t.Run("implements the elm architecture", func(t *testing.T) {
+		methods := []string{
+			"func (m Model) Init() tea.Cmd",
+			"func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd)",
+			"func (m Model) View() string",
+		}
+		for _, method := range methods {
+			if !strings.Contains(content, method) {
+				t.Errorf("model.go doesn't define %s", method)
+			}
+		}
+	})
+
+	t.Run("mirrors the elm counter messages", func(t *testing.T) {
+		elm := NewViteElmGenerator().mainElmTemplate()
+		for _, msg := range []string{"Increment", "Decrement"} {
+			if !strings.Contains(elm, msg) {
+				t.Fatalf("Elm template no longer defines %s", msg)
+			}
+			if !strings.Contains(content, "case "+msg+":") {
+				t.Errorf("model.go doesn't handle Msg %s", msg)
+			}
+		}
+	})
+
+	t.Run("quits on quit keys", func(t *testing.T) {
+		if !strings.Contains(content, "tea.Quit") {
+			t.Error("model.go never returns tea.Quit")
+		}
+	})
+}
+
+func TestGoTUIGenerator_Keys(t *testing.T) {
+	gen := NewGoTUIGenerator()
+	content := gen.keysTemplate()
+
+	bindings := []string{`"+", "k", "up"`, `"-", "j", "down"`, `"q", "esc", "ctrl+c"`}
+	for _, b := range bindings {
+		if !strings.Contains(content, b) {
+			t.Errorf("keys.go doesn't bind %s", b)
+		}
+	}
+}
+
+func TestGoTUIGenerator_ModelTest(t *testing.T) {
+	gen := NewGoTUIGenerator()
+	content := gen.modelTestTemplate("mytui")
+
+	t.Run("has update flag", func(t *testing.T) {
+		if !strings.Contains(content, `flag.Bool("update"`) {
+			t.Error("model_test.go doesn't define the -update flag")
+		}
+	})
+
+	t.Run("covers every golden case", func(t *testing.T) {
+		for _, c := range tuiGoldenCases {
+			if !strings.Contains(content, `name: "`+c.name+`"`) {
+				t.Errorf("model_test.go doesn't cover golden case %s", c.name)
+			}
+		}
+	})
+
+	t.Run("renders with project name", func(t *testing.T) {
+		if !strings.Contains(content, `New("mytui")`) {
+			t.Error("model_test.go doesn't render the model with the project name")
+		}
+	})
+}
+
+func TestGoTUIGenerator_ViewGolden(t *testing.T) {
+	gen := NewGoTUIGenerator()
+
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{count: 0, want: "mytui\n\n  [-]  0  [+]\n\n+/k/up increment • -/j/down decrement • q/esc/ctrl+c quit\n"},
+		{count: -2, want: "mytui\n\n  [-]  -2  [+]\n\n+/k/up increment • -/j/down decrement • q/esc/ctrl+c quit\n"},
+	}
+
+	for _, tt := range tests {
+		if got := gen.viewGolden("mytui", tt.count); got != tt.want {
+			t.Errorf("viewGolden(%d) = %q, want %q", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestGoTUIGenerator_GoMod(t *testing.T) {
+	gen := NewGoTUIGenerator()
+	content := gen.goModTemplate("github.com/user/mytui")
+
+	if !strings.Contains(content, "module github.com/user/mytui") {
+		t.Error("go.mod doesn't declare correct module path")
+	}
+	if !strings.Contains(content, "github.com/charmbracelet/bubbletea") {
+		t.Error("go.mod doesn't require bubbletea")
+	}
+}
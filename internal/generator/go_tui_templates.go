@@ -0,0 +1,383 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tuiGoldenCases are the view scenarios covered by the generated golden test.
+// count is the counter value after pressing keys on a fresh model.
+var tuiGoldenCases = []struct {
+	name  string
+	keys  []string
+	count int
+}{
+	{name: "initial", count: 0},
+	{name: "incremented", keys: []string{"+", "+", "k"}, count: 3},
+	{name: "decremented", keys: []string{"-", "down"}, count: -2},
+}
+
+func (g *GoTUIGenerator) mainGoTemplate(projectName, modulePath string) string {
+	return fmt.Sprintf(`package main
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"%s/internal/tui"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	model := tui.New("%s")
+
+	// Without a terminal (pipes, redirects, CI) fall back to line mode,
+	// driving the same model without Bubble Tea.
+	if !isTerminal(os.Stdout) {
+		return tui.RunPlain(model, os.Stdin, os.Stdout)
+	}
+
+	_, err := tea.NewProgram(model).Run()
+	return err
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+`, modulePath, projectName)
+}
+
+func (g *GoTUIGenerator) modelTemplate() string {
+	return `// Package tui implements the terminal UI with the Elm architecture: a Model
+// holds all state, Update turns messages into a new Model and View renders
+// the Model as a string.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Msg is a counter action, mirroring the Msg type of the Elm counter.
+type Msg int
+
+const (
+	Increment Msg = iota
+	Decrement
+)
+
+// Model holds the application state.
+type Model struct {
+	title string
+	count int
+	keys  keyMap
+}
+
+// New returns the initial model.
+func New(title string) Model {
+	return Model{title: title, keys: defaultKeyMap()}
+}
+
+// Init is called once when the program starts. There is no initial command.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update translates Bubble Tea messages into counter updates.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		next, quit := m.Press(msg.String())
+		if quit {
+			return next, tea.Quit
+		}
+		return next, nil
+	case Msg:
+		return m.update(msg), nil
+	}
+	return m, nil
+}
+
+// Press applies a key press and reports whether it asks to quit. It does not
+// depend on Bubble Tea, so the plain runner and tests can drive it directly.
+func (m Model) Press(key string) (Model, bool) {
+	switch {
+	case m.keys.increment.matches(key):
+		return m.update(Increment), false
+	case m.keys.decrement.matches(key):
+		return m.update(Decrement), false
+	case m.keys.quit.matches(key):
+		return m, true
+	}
+	return m, false
+}
+
+// update is the counter logic, the same as update in the Elm template.
+func (m Model) update(msg Msg) Model {
+	switch msg {
+	case Increment:
+		m.count++
+	case Decrement:
+		m.count--
+	}
+	return m
+}
+
+// View renders the model.
+func (m Model) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", m.title)
+	fmt.Fprintf(&b, "  [-]  %d  [+]\n\n", m.count)
+	fmt.Fprintf(&b, "%s\n", m.keys.help())
+	return b.String()
+}
+`
+}
+
+func (g *GoTUIGenerator) keysTemplate() string {
+	return `package tui
+
+import "strings"
+
+// binding maps one action to the keys that trigger it.
+type binding struct {
+	keys []string
+	help string
+}
+
+func (b binding) matches(key string) bool {
+	for _, k := range b.keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// keyMap holds the key bindings of the application.
+type keyMap struct {
+	increment binding
+	decrement binding
+	quit      binding
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		increment: binding{keys: []string{"+", "k", "up"}, help: "increment"},
+		decrement: binding{keys: []string{"-", "j", "down"}, help: "decrement"},
+		quit:      binding{keys: []string{"q", "esc", "ctrl+c"}, help: "quit"},
+	}
+}
+
+// help renders the bindings as a single help line.
+func (k keyMap) help() string {
+	bindings := []binding{k.increment, k.decrement, k.quit}
+	parts := make([]string, len(bindings))
+	for i, b := range bindings {
+		parts[i] = strings.Join(b.keys, "/") + " " + b.help
+	}
+	return strings.Join(parts, " • ")
+}
+`
+}
+
+func (g *GoTUIGenerator) plainTemplate() string {
+	return `package tui
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// RunPlain drives the model without Bubble Tea. It is used when stdout is not
+// a terminal: every input line is one key press, and the view is printed
+// after each update until input ends or a quit key is read.
+func RunPlain(m Model, in io.Reader, out io.Writer) error {
+	if _, err := io.WriteString(out, m.View()); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		key := strings.TrimSpace(scanner.Text())
+		if key == "" {
+			continue
+		}
+
+		next, quit := m.Press(key)
+		if quit {
+			return nil
+		}
+		m = next
+
+		if _, err := io.WriteString(out, "\n"+m.View()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+`
+}
+
+func (g *GoTUIGenerator) modelTestTemplate(projectName string) string {
+	var cases strings.Builder
+	for _, c := range tuiGoldenCases {
+		if len(c.keys) == 0 {
+			fmt.Fprintf(&cases, "\t\t{name: %q},\n", c.name)
+			continue
+		}
+		keys := make([]string, len(c.keys))
+		for i, k := range c.keys {
+			keys[i] = fmt.Sprintf("%q", k)
+		}
+		fmt.Fprintf(&cases, "\t\t{name: %q, keys: []string{%s}},\n", c.name, strings.Join(keys, ", "))
+	}
+
+	return fmt.Sprintf(`package tui
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/")
+
+func TestView_Golden(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+	}{
+%s	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(%q)
+			for _, key := range tt.keys {
+				m, _ = m.Press(key)
+			}
+			got := m.View()
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatalf("Failed to update golden file: %%v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %%v", err)
+			}
+			if got != string(want) {
+				t.Errorf("View() mismatch\n--- want\n%%s\n--- got\n%%s", want, got)
+			}
+		})
+	}
+}
+
+func TestPress_Quit(t *testing.T) {
+	for _, key := range []string{"q", "esc", "ctrl+c"} {
+		if _, quit := New("test").Press(key); !quit {
+			t.Errorf("Press(%%q) didn't quit", key)
+		}
+	}
+}
+
+func TestRunPlain(t *testing.T) {
+	var out strings.Builder
+	input := strings.NewReader("+\n+\nq\n+\n")
+
+	if err := RunPlain(New("test"), input, &out); err != nil {
+		t.Fatalf("RunPlain() failed: %%v", err)
+	}
+
+	if !strings.Contains(out.String(), "[-]  2  [+]") {
+		t.Errorf("Expected count 2 in output, got:\n%%s", out.String())
+	}
+	if strings.Contains(out.String(), "[-]  3  [+]") {
+		t.Error("RunPlain() kept reading input after quit")
+	}
+}
+`, cases.String(), projectName)
+}
+
+// viewGolden renders what Model.View returns for the given counter value.
+// It must stay in sync with modelTemplate and keysTemplate.
+func (g *GoTUIGenerator) viewGolden(projectName string, count int) string {
+	return fmt.Sprintf("%s\n\n  [-]  %d  [+]\n\n%s\n", projectName, count,
+		"+/k/up increment • -/j/down decrement • q/esc/ctrl+c quit")
+}
+
+func (g *GoTUIGenerator) goModTemplate(modulePath string) string {
+	return fmt.Sprintf(`module %s
+
+go 1.23
+
+require github.com/charmbracelet/bubbletea %s
+`, modulePath, g.base.versions.goModule("github.com/charmbracelet/bubbletea"))
+}
+
+func (g *GoTUIGenerator) readmeTemplate(projectName string) string {
+	return fmt.Sprintf(`# %s
+
+Terminal UI created with projectstarter
+
+## Installation
+
+`+goSumReadme+`
+## Usage
+
+`+"```bash"+`
+go run ./cmd/%s
+`+"```"+`
+
+| Key | Action |
+| --- | --- |
+| `+"`+` `k` `↑`"+` | Increment |
+| `+"`-` `j` `↓`"+` | Decrement |
+| `+"`q` `esc` `ctrl+c`"+` | Quit |
+
+When stdout is not a terminal the app runs in plain mode instead: each line
+read from stdin is one key press and the view is printed after every update.
+
+`+"```bash"+`
+printf '+\n+\n-\n' | go run ./cmd/%s | cat
+`+"```"+`
+
+## Testing
+
+`+"```bash"+`
+go test ./...
+`+"```"+`
+
+The view is covered by golden files in `+"`internal/tui/testdata/`"+`. After an
+intentional change to the view, regenerate them with:
+
+`+"```bash"+`
+go test ./internal/tui -update
+`+"```"+`
+
+## License
+
+MIT
+`, projectName, projectName, projectName)
+}
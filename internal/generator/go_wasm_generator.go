@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+type GoWasmGenerator struct {
+	base *GoGenerator
+	// goroot locates the Go installation wasm_exec.js is copied from
+	goroot func() (string, error)
+}
+
+func NewGoWasmGenerator() *GoWasmGenerator {
+	return &GoWasmGenerator{
+		base:   NewGoGenerator(),
+		goroot: localGOROOT,
+	}
+}
+
+// WithGoVersion sets the go and toolchain directives of go.mod, see
+// GoGenerator.WithGoVersion
+func (g *GoWasmGenerator) WithGoVersion(goVersion, toolchain string) error {
+	return g.base.WithGoVersion(goVersion, toolchain)
+}
+
+// WithVersions overrides dependency versions of the embedded catalog, see
+// GoGenerator.WithVersions
+func (g *GoWasmGenerator) WithVersions(overrides Catalog) error {
+	return g.base.WithVersions(overrides)
+}
+
+// UseMakefile writes the project tasks to a Makefile instead of Taskfile.yml
+func (g *GoWasmGenerator) UseMakefile() {
+	g.base.UseMakefile()
+}
+
+// Generate creates a new Go WebAssembly project with the given name
+func (g *GoWasmGenerator) Generate(projectName string) error {
+	// Module path handling is shared with the plain Go generator
+	modulePath, projectDir := g.base.parseProjectName(projectName)
+
+	// Check if directory already exists
+	if _, err := os.Stat(projectDir); err == nil {
+		return fmt.Errorf("directory '%s' already exists", projectDir)
+	}
+
+	// Create project structure
+	if err := g.createStructure(projectDir, modulePath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createStructure creates all project files and directories
+func (g *GoWasmGenerator) createStructure(projectDir, modulePath string) error {
+	dirs, files, err := g.layout(modulePath)
+	if err != nil {
+		return err
+	}
+	if err := g.base.toolchain.apply(files); err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, dirs, files); err != nil {
+		return err
+	}
+	return g.base.tidy(projectDir)
+}
+
+// layout returns the directories and files of a WebAssembly project, relative
+// to the project root. It fails when wasm_exec.js can't be found locally.
+func (g *GoWasmGenerator) layout(modulePath string) ([]string, map[string]string, error) {
+	// Extract short name from path for use in templates
+	shortName := filepath.Base(modulePath)
+	counterDir := filepath.Join("internal", "counter")
+
+	// wasm_exec.js must match the Go version that builds main.wasm, so it
+	// is copied from the local toolchain instead of being templated
+	wasmExec, err := g.wasmExecJS()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dirs := []string{
+		filepath.Join("cmd", shortName),
+		filepath.Join("cmd", "serve"),
+		counterDir,
+		"web",
+	}
+
+	files := map[string]string{
+		filepath.Join("cmd", shortName, "main.go"):   g.mainGoTemplate(shortName, modulePath),
+		filepath.Join("cmd", "serve", "main.go"):     g.serveTemplate(),
+		filepath.Join(counterDir, "counter.go"):      g.counterTemplate(),
+		filepath.Join(counterDir, "counter_test.go"): g.counterTestTemplate(),
+		filepath.Join("web", "index.html"):           g.indexHTMLTemplate(shortName),
+		filepath.Join("web", "wasm_exec.js"):         wasmExec,
+		"go.mod":                                     g.goModTemplate(modulePath),
+		"README.md":                                  g.readmeTemplate(shortName),
+		"LICENSE":                                    g.base.licenseTemplate(),
+		".gitignore":                                 g.gitignoreTemplate(),
+	}
+	files[g.base.runner.filename()] = g.tasksTemplate(shortName)
+
+	return dirs, files, nil
+}
+
+// wasmExecJS reads wasm_exec.js from the local Go installation. Go 1.24
+// moved it from misc/wasm to lib/wasm, so both locations are tried.
+func (g *GoWasmGenerator) wasmExecJS() (string, error) {
+	goroot, err := g.goroot()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate GOROOT for wasm_exec.js: %w", err)
+	}
+
+	candidates := []string{
+		filepath.Join(goroot, "lib", "wasm", "wasm_exec.js"),
+		filepath.Join(goroot, "misc", "wasm", "wasm_exec.js"),
+	}
+
+	for _, path := range candidates {
+		content, err := os.ReadFile(path)
+		if err == nil {
+			return string(content), nil
+		}
+	}
+
+	return "", fmt.Errorf("wasm_exec.js not found in GOROOT %s", goroot)
+}
+
+// localGOROOT asks the go command on PATH for its GOROOT
+func localGOROOT() (string, error) {
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOROOT: %w", err)
+	}
+
+	goroot := strings.TrimSpace(string(out))
+	if goroot == "" {
+		return "", fmt.Errorf("go env GOROOT returned nothing")
+	}
+	return goroot, nil
+}
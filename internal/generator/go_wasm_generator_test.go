@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeGOROOT returns a goroot func pointing at a temp dir that holds
+// wasm_exec.js under rel, or nothing when rel is empty.
+func fakeGOROOT(t *testing.T, rel string) func() (string, error) {
+	t.Helper()
+	root := t.TempDir()
+	if rel != "" {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create fake GOROOT: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("// wasm_exec.js from "+rel), 0o644); err != nil {
+			t.Fatalf("Failed to create fake wasm_exec.js: %v", err)
+		}
+	}
+	return func() (string, error) { return root, nil }
+}
+
+func TestGoWasmGenerator_Generate(t *testing.T) {
+	t.Run("creates all required files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		gen := NewGoWasmGenerator()
+		gen.goroot = fakeGOROOT(t, "lib/wasm/wasm_exec.js")
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		if err := gen.Generate("github.com/user/mywasm"); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		requiredFiles := []string{
+			"cmd/mywasm/main.go",
+			"cmd/serve/main.go",
+			"internal/counter/counter.go",
+			"internal/counter/counter_test.go",
+			"web/index.html",
+			"web/wasm_exec.js",
+			"go.mod",
+			"Taskfile.yml",
+			"README.md",
+			"LICENSE",
+			".gitignore",
+		}
+
+		for _, file := range requiredFiles {
+			fullPath := filepath.Join("mywasm", file)
+			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+				t.Errorf("Required file not created: %s", file)
+			}
+		}
+	})
+
+	t.Run("fails without wasm_exec.js and writes nothing", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		gen := NewGoWasmGenerator()
+		gen.goroot = fakeGOROOT(t, "")
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		err = gen.Generate("mywasm")
+		if err == nil || !strings.Contains(err.Error(), "wasm_exec.js not found") {
+			t.Errorf("Expected 'wasm_exec.js not found' error, got: %v", err)
+		}
+		if _, err := os.Stat("mywasm"); err == nil {
+			t.Error("Project directory created despite the error")
+		}
+	})
+}
+
+func TestGoWasmGenerator_WasmExecJS(t *testing.T) {
+	tests := []struct {
+		name string
+		rel  string
+	}{
+		{name: "go 1.24 and later", rel: "lib/wasm/wasm_exec.js"},
+		{name: "before go 1.24", rel: "misc/wasm/wasm_exec.js"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGoWasmGenerator()
+			gen.goroot = fakeGOROOT(t, tt.rel)
+
+			content, err := gen.wasmExecJS()
+			if err != nil {
+				t.Fatalf("wasmExecJS() failed: %v", err)
+			}
+			if content != "// wasm_exec.js from "+tt.rel {
+				t.Errorf("Unexpected wasm_exec.js content: %q", content)
+			}
+		})
+	}
+}
+
+func TestGoWasmGenerator_MainGo(t *testing.T) {
+	gen := NewGoWasmGenerator()
+	content := gen.mainGoTemplate("mywasm", "github.com/user/mywasm")
+
+	t.Run("only builds for js/wasm", func(t *testing.T) {
+		if !strings.HasPrefix(content, "//go:build js && wasm\n") {
+			t.Error("main.go isn't limited to js/wasm")
+		}
+	})
+
+	t.Run("uses syscall/js", func(t *testing.T) {
+		if !strings.Contains(content, `"syscall/js"`) {
+			t.Error("main.go doesn't import syscall/js")
+		}
+		if !strings.Contains(content, "js.FuncOf") {
+			t.Error("main.go doesn't register callbacks")
+		}
+	})
+
+	t.Run("keeps running", func(t *testing.T) {
+		if !strings.Contains(content, "select {}") {
+			t.Error("main.go returns and would release its callbacks")
+		}
+	})
+}
+
+func TestGoWasmGenerator_IndexHtml(t *testing.T) {
+	gen := NewGoWasmGenerator()
+	content := gen.indexHTMLTemplate("mywasm")
+
+	for _, want := range []string{`<script src="wasm_exec.js">`, `fetch("main.wasm")`, "go.run(", `id="app"`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("index.html doesn't contain %s", want)
+		}
+	}
+}
+
+func TestGoWasmGenerator_Taskfile(t *testing.T) {
+	gen := NewGoWasmGenerator()
+	content := gen.tasksTemplate("mywasm")
+
+	t.Run("builds for js/wasm", func(t *testing.T) {
+		for _, want := range []string{"GOOS: js", "GOARCH: wasm", "go build -o web/main.wasm ./cmd/mywasm"} {
+			if !strings.Contains(content, want) {
+				t.Errorf("Taskfile.yml build task doesn't contain %s", want)
+			}
+		}
+	})
+}
+
+func TestGoWasmGenerator_Gitignore(t *testing.T) {
+	gen := NewGoWasmGenerator()
+	content := gen.gitignoreTemplate()
+
+	if !strings.Contains(content, "web/main.wasm") {
+		t.Error(".gitignore doesn't ignore the build output")
+	}
+	if !strings.Contains(content, "bin/") {
+		t.Error(".gitignore lost the Go defaults")
+	}
+}
@@ -0,0 +1,220 @@
+package generator
+
+import "fmt"
+
+func (g *GoWasmGenerator) mainGoTemplate(projectName, modulePath string) string {
+	return fmt.Sprintf(`//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"%s/internal/counter"
+)
+
+func main() {
+	document := js.Global().Get("document")
+	app := document.Call("getElementById", "app")
+
+	var c counter.Counter
+	label := document.Call("createElement", "span")
+	render := func() {
+		label.Set("textContent", c.String())
+	}
+
+	app.Call("append",
+		button(document, "-", func() { c.Decrement(); render() }),
+		label,
+		button(document, "+", func() { c.Increment(); render() }),
+	)
+	render()
+
+	fmt.Println("%s loaded")
+
+	// Keep the Go runtime alive so the callbacks above keep working
+	select {}
+}
+
+// button creates a <button> that calls onClick when pressed.
+func button(document js.Value, text string, onClick func()) js.Value {
+	b := document.Call("createElement", "button")
+	b.Set("textContent", text)
+	b.Call("addEventListener", "click", js.FuncOf(func(this js.Value, args []js.Value) any {
+		onClick()
+		return nil
+	}))
+	return b
+}
+`, modulePath, projectName)
+}
+
+func (g *GoWasmGenerator) counterTemplate() string {
+	return `// Package counter holds the app state. It has no syscall/js dependency, so
+// it builds and tests on the host as well as in the browser.
+package counter
+
+import "strconv"
+
+// Counter is a number that goes up and down.
+type Counter struct {
+	count int
+}
+
+func (c *Counter) Increment() {
+	c.count++
+}
+
+func (c *Counter) Decrement() {
+	c.count--
+}
+
+func (c *Counter) String() string {
+	return strconv.Itoa(c.count)
+}
+`
+}
+
+func (g *GoWasmGenerator) counterTestTemplate() string {
+	return `package counter
+
+import "testing"
+
+func TestCounter(t *testing.T) {
+	var c Counter
+	c.Increment()
+	c.Increment()
+	c.Decrement()
+
+	if got := c.String(); got != "1" {
+		t.Errorf("Expected count 1, got %s", got)
+	}
+}
+`
+}
+
+func (g *GoWasmGenerator) serveTemplate() string {
+	return `// Command serve serves web/ for trying the WebAssembly build locally.
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dir := flag.String("dir", "web", "directory to serve")
+	flag.Parse()
+
+	slog.Info("Serving", "dir", *dir, "addr", *addr)
+	if err := http.ListenAndServe(*addr, http.FileServer(http.Dir(*dir))); err != nil {
+		slog.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+`
+}
+
+func (g *GoWasmGenerator) indexHTMLTemplate(projectName string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1.0">
+  <title>%s</title>
+</head>
+<body>
+  <div id="app"></div>
+  <script src="wasm_exec.js"></script>
+  <script>
+    const go = new Go();
+    WebAssembly.instantiateStreaming(fetch("main.wasm"), go.importObject)
+      .then((result) => go.run(result.instance))
+      .catch((err) => console.error(err));
+  </script>
+</body>
+</html>
+`, projectName)
+}
+
+func (g *GoWasmGenerator) goModTemplate(modulePath string) string {
+	return fmt.Sprintf(`module %s
+
+go 1.21
+`, modulePath)
+}
+
+func (g *GoWasmGenerator) tasksTemplate(projectName string) string {
+	tasks := []task{
+		{name: "dev", desc: "Build and serve on http://localhost:8080", aliases: []string{"d", "serve"}, deps: []string{"build"}, cmds: []string{"go run ./cmd/serve"}},
+		{name: "test", desc: "Run host tests", aliases: []string{"t"}, cmds: []string{"go test ./..."}},
+		{
+			name:    "build",
+			desc:    "Build the WebAssembly module into web/main.wasm",
+			aliases: []string{"b"},
+			env:     []string{"GOOS=js", "GOARCH=wasm"},
+			cmds:    []string{"go build -o web/main.wasm ./cmd/" + projectName},
+		},
+		{
+			name:    "lint",
+			desc:    "Vet the host and js/wasm code and list unformatted files",
+			aliases: []string{"l"},
+			cmds:    append([]string{"GOOS=js GOARCH=wasm go vet ./cmd/" + projectName}, goLintCmds...),
+		},
+		{name: "clean", desc: "Remove build output", cmds: []string{"rm -f web/main.wasm"}},
+	}
+
+	return g.base.runner.render("dev", tasks)
+}
+
+func (g *GoWasmGenerator) gitignoreTemplate() string {
+	return g.base.gitignoreTemplate() + `
+# WebAssembly build output
+web/main.wasm
+`
+}
+
+func (g *GoWasmGenerator) readmeTemplate(projectName string) string {
+	return fmt.Sprintf(`# %[1]s
+
+Go WebAssembly project created with projectstarter
+
+## Build
+
+`+"```bash"+`
+GOOS=js GOARCH=wasm go build -o web/main.wasm ./cmd/%[1]s
+`+"```"+`
+
+Or: `+"`%[2]s`"+`
+
+## Usage
+
+`+"```bash"+`
+go run ./cmd/serve
+`+"```"+`
+
+Open http://localhost:8080. `+"`%[3]s`"+` builds and serves in one step.
+
+`+"`web/wasm_exec.js`"+` was copied from the Go installation that generated this
+project and must match the Go version that builds `+"`main.wasm`"+`. After
+upgrading Go, copy it again:
+
+`+"```bash"+`
+cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" web/
+`+"```"+`
+
+## Testing
+
+`+"```bash"+`
+go test ./...
+`+"```"+`
+
+## License
+
+MIT
+`, projectName, g.base.runner.command("build"), g.base.runner.command("dev"))
+}
@@ -0,0 +1,262 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+type GoWorkspaceGenerator struct {
+	base *GoGenerator
+	// modules are the workspace members created by Generate, relative to
+	// the workspace root. Members under pkg/ are libraries, the others are
+	// applications that depend on every library.
+	modules []string
+}
+
+func NewGoWorkspaceGenerator() *GoWorkspaceGenerator {
+	return &GoWorkspaceGenerator{
+		base:    NewGoGenerator(),
+		modules: []string{"api", "worker", "pkg/shared"},
+	}
+}
+
+// WithLogging selects the slog handler and log level source of the applications' main.go, see
+// GoGenerator.WithLogging
+func (g *GoWorkspaceGenerator) WithLogging(format, levelFrom string) error {
+	return g.base.WithLogging(format, levelFrom)
+}
+
+// WithGoVersion sets the go and toolchain directives of go.mod, see
+// GoGenerator.WithGoVersion
+func (g *GoWorkspaceGenerator) WithGoVersion(goVersion, toolchain string) error {
+	return g.base.WithGoVersion(goVersion, toolchain)
+}
+
+// WithVersions overrides dependency versions of the embedded catalog, see
+// GoGenerator.WithVersions
+func (g *GoWorkspaceGenerator) WithVersions(overrides Catalog) error {
+	return g.base.WithVersions(overrides)
+}
+
+// UseMakefile writes the project tasks to a Makefile instead of Taskfile.yml
+func (g *GoWorkspaceGenerator) UseMakefile() {
+	g.base.UseMakefile()
+}
+
+// Generate creates a new multi-module Go workspace with the given name
+func (g *GoWorkspaceGenerator) Generate(projectName string) error {
+	// Module path handling is shared with the plain Go generator
+	modulePath, projectDir := g.base.parseProjectName(projectName)
+
+	// Check if directory already exists
+	if _, err := os.Stat(projectDir); err == nil {
+		return fmt.Errorf("directory '%s' already exists", projectDir)
+	}
+
+	// Create project structure
+	if err := g.createStructure(projectDir, modulePath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddModule creates a new module at dir inside the workspace rooted at root
+// and adds it to go.work. The module path is derived from the existing
+// members, and new applications depend on every library already there.
+func (g *GoWorkspaceGenerator) AddModule(root, dir string) error {
+	dir = path.Clean(filepath.ToSlash(dir))
+	if dir == "." || path.IsAbs(dir) || strings.HasPrefix(dir, "../") || dir == ".." {
+		return fmt.Errorf("module directory '%s' must be inside the workspace", dir)
+	}
+
+	goWorkPath := filepath.Join(root, "go.work")
+	data, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return fmt.Errorf("failed to read go.work: %w", err)
+	}
+
+	work, err := modfile.ParseWork(goWorkPath, data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	var members []string
+	for _, use := range work.Use {
+		members = append(members, path.Clean(use.Path))
+	}
+
+	for _, member := range members {
+		if member == dir {
+			return fmt.Errorf("module '%s' is already in go.work", dir)
+		}
+	}
+
+	moduleDir := filepath.Join(root, filepath.FromSlash(dir))
+	if _, err := os.Stat(moduleDir); err == nil {
+		return fmt.Errorf("directory '%s' already exists", moduleDir)
+	}
+
+	prefix, err := workspaceModulePrefix(root, members)
+	if err != nil {
+		return err
+	}
+
+	var libs []string
+	for _, member := range members {
+		if isLibraryModule(member) {
+			libs = append(libs, member)
+		}
+	}
+
+	// New modules follow the workspace's Go version
+	toolchain := g.base.toolchain
+	if toolchain.goVersion == "" && work.Go != nil {
+		toolchain.goVersion = work.Go.Version
+	}
+
+	dirs, files := g.moduleLayout(prefix, dir, libs)
+	if err := toolchain.apply(files); err != nil {
+		return err
+	}
+	if err := writeProject(moduleDir, dirs, files); err != nil {
+		return err
+	}
+
+	if err := work.AddUse("./"+dir, ""); err != nil {
+		return fmt.Errorf("failed to add %s to go.work: %w", dir, err)
+	}
+	work.SortBlocks()
+	work.Cleanup()
+
+	if err := os.WriteFile(goWorkPath, modfile.Format(work.Syntax), 0o644); err != nil {
+		return fmt.Errorf("failed to update go.work: %w", err)
+	}
+
+	return g.base.tidy(moduleDir)
+}
+
+// createStructure creates all project files and directories
+func (g *GoWorkspaceGenerator) createStructure(projectDir, modulePath string) error {
+	dirs, files := g.layout(modulePath)
+	if err := g.base.toolchain.apply(files); err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, dirs, files); err != nil {
+		return err
+	}
+
+	var moduleDirs []string
+	for _, dir := range g.modules {
+		moduleDirs = append(moduleDirs, filepath.Join(projectDir, filepath.FromSlash(dir)))
+	}
+	return tidyModules(g.base.tidy, moduleDirs...)
+}
+
+// layout returns the directories and files of the workspace, relative to the
+// workspace root
+func (g *GoWorkspaceGenerator) layout(modulePath string) ([]string, map[string]string) {
+	shortName := filepath.Base(modulePath)
+
+	var libs []string
+	for _, dir := range g.modules {
+		if isLibraryModule(dir) {
+			libs = append(libs, dir)
+		}
+	}
+
+	var dirs []string
+	files := map[string]string{
+		"go.work":    g.goWorkTemplate(g.modules),
+		"README.md":  g.readmeTemplate(shortName),
+		"LICENSE":    g.base.licenseTemplate(),
+		".gitignore": g.gitignoreTemplate(),
+	}
+	files[g.base.runner.filename()] = g.base.runner.render("dev", g.tasks())
+
+	for _, dir := range g.modules {
+		moduleDirs, moduleFiles := g.moduleLayout(modulePath, dir, libs)
+		moduleDirs, moduleFiles = prefixLayout(filepath.FromSlash(dir), moduleDirs, moduleFiles)
+
+		dirs = append(dirs, filepath.FromSlash(dir))
+		dirs = append(dirs, moduleDirs...)
+		for name, content := range moduleFiles {
+			files[name] = content
+		}
+	}
+
+	return dirs, files
+}
+
+// moduleLayout returns the layout of the member at dir, relative to dir.
+// Applications reuse the Go generator layout; the workspace root holds the
+// shared README, LICENSE, .gitignore and tasks instead.
+func (g *GoWorkspaceGenerator) moduleLayout(prefix, dir string, libs []string) ([]string, map[string]string) {
+	modulePath := prefix + "/" + dir
+
+	if isLibraryModule(dir) {
+		name := packageName(dir)
+		files := map[string]string{
+			"go.mod":          g.libraryGoModTemplate(modulePath),
+			name + ".go":      g.libraryTemplate(name),
+			name + "_test.go": g.libraryTestTemplate(name),
+		}
+		return nil, files
+	}
+
+	dirs, files := g.base.layout(modulePath)
+	delete(files, "README.md")
+	delete(files, "LICENSE")
+	delete(files, ".gitignore")
+	delete(files, g.base.runner.filename())
+
+	shortName := filepath.Base(modulePath)
+	files[filepath.Join("cmd", shortName, "main.go")] = g.mainGoTemplate(shortName, prefix, libs)
+	files["go.mod"] = g.goModTemplate(modulePath, prefix, dir, libs)
+
+	return dirs, files
+}
+
+// workspaceModulePrefix derives the workspace module path from its members:
+// a member at "api" with module path "github.com/user/mono/api" gives
+// "github.com/user/mono".
+func workspaceModulePrefix(root string, members []string) (string, error) {
+	for _, member := range members {
+		goModPath := filepath.Join(root, filepath.FromSlash(member), "go.mod")
+		data, err := os.ReadFile(goModPath)
+		if err != nil {
+			continue
+		}
+
+		modulePath := modfile.ModulePath(data)
+		if prefix, ok := strings.CutSuffix(modulePath, "/"+member); ok && prefix != "" {
+			return prefix, nil
+		}
+	}
+
+	return "", fmt.Errorf("can't derive the workspace module path from the modules in go.work")
+}
+
+// isLibraryModule reports whether the workspace member at dir is a library
+func isLibraryModule(dir string) bool {
+	return strings.HasPrefix(dir, "pkg/")
+}
+
+// packageName turns the last element of dir into a valid package name
+func packageName(dir string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(path.Base(dir)) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' && b.Len() > 0 {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "lib"
+	}
+	return b.String()
+}
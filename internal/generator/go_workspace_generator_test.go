@@ -0,0 +1,268 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoWorkspaceGenerator_Generate(t *testing.T) {
+	t.Run("creates all required files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		gen := NewGoWorkspaceGenerator()
+		if err := gen.Generate("github.com/user/mono"); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		requiredFiles := []string{
+			"go.work",
+			"README.md",
+			"LICENSE",
+			".gitignore",
+			"api/go.mod",
+			"api/cmd/api/main.go",
+			"api/cmd/api/main_test.go",
+			"worker/go.mod",
+			"worker/cmd/worker/main.go",
+			"pkg/shared/go.mod",
+			"pkg/shared/shared.go",
+			"pkg/shared/shared_test.go",
+		}
+
+		for _, file := range requiredFiles {
+			fullPath := filepath.Join("mono", file)
+			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+				t.Errorf("Required file not created: %s", file)
+			}
+		}
+	})
+
+	t.Run("fails when directory already exists", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		if err := os.MkdirAll("existing", 0o755); err != nil {
+			t.Fatalf("Failed to create test directory: %v", err)
+		}
+
+		gen := NewGoWorkspaceGenerator()
+		err = gen.Generate("existing")
+		if err == nil {
+			t.Fatal("Expected error when directory exists, got nil")
+		}
+		if !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("Expected 'already exists' error, got: %v", err)
+		}
+	})
+}
+
+func TestGoWorkspaceGenerator_Layout(t *testing.T) {
+	gen := NewGoWorkspaceGenerator()
+	_, files := gen.layout("github.com/user/mono")
+
+	t.Run("uses every module in go.work", func(t *testing.T) {
+		for _, dir := range []string{"./api", "./worker", "./pkg/shared"} {
+			if !strings.Contains(files["go.work"], "\t"+dir+"\n") {
+				t.Errorf("go.work doesn't use %s", dir)
+			}
+		}
+	})
+
+	t.Run("gives each module its own path", func(t *testing.T) {
+		modules := map[string]string{
+			"api/go.mod":        "module github.com/user/mono/api\n",
+			"worker/go.mod":     "module github.com/user/mono/worker\n",
+			"pkg/shared/go.mod": "module github.com/user/mono/pkg/shared\n",
+		}
+		for file, want := range modules {
+			if !strings.HasPrefix(files[filepath.FromSlash(file)], want) {
+				t.Errorf("%s doesn't start with %q", file, want)
+			}
+		}
+	})
+
+	t.Run("wires applications to the shared library", func(t *testing.T) {
+		goMod := files[filepath.Join("api", "go.mod")]
+		if !strings.Contains(goMod, "github.com/user/mono/pkg/shared "+workspaceLibVersion) {
+			t.Error("api/go.mod doesn't require pkg/shared")
+		}
+		if !strings.Contains(goMod, "replace github.com/user/mono/pkg/shared => ../pkg/shared") {
+			t.Error("api/go.mod doesn't replace pkg/shared with the local directory")
+		}
+
+		main := files[filepath.Join("api", "cmd", "api", "main.go")]
+		if !strings.Contains(main, `"github.com/user/mono/pkg/shared"`) {
+			t.Error("api main.go doesn't import pkg/shared")
+		}
+		if !strings.Contains(main, `shared.Greeting("api")`) {
+			t.Error("api main.go doesn't use pkg/shared")
+		}
+	})
+
+	t.Run("builds applications like the go generator", func(t *testing.T) {
+		base := NewGoGenerator()
+
+		goMod := files[filepath.Join("worker", "go.mod")]
+		if want := base.goModTemplate("github.com/user/mono/worker"); !strings.HasPrefix(goMod, want) {
+			t.Errorf("worker/go.mod doesn't start with the go generator's go.mod %q:\n%s", want, goMod)
+		}
+
+		main := files[filepath.Join("worker", "cmd", "worker", "main.go")]
+		for _, want := range []string{
+			"NoColor:    !isTerminal(os.Stderr) || os.Getenv(\"NO_COLOR\") != \"\",",
+			"\t\"github.com/lmittmann/tint\"\n\n\t\"github.com/user/mono/pkg/shared\"\n)",
+		} {
+			if !strings.Contains(main, want) {
+				t.Errorf("worker main.go doesn't contain %q:\n%s", want, main)
+			}
+		}
+	})
+
+	t.Run("keeps root files out of the modules", func(t *testing.T) {
+		for _, file := range []string{"README.md", "LICENSE", ".gitignore"} {
+			if _, ok := files[filepath.Join("api", file)]; ok {
+				t.Errorf("api/ has its own %s", file)
+			}
+		}
+	})
+}
+
+func TestGoWorkspaceGenerator_Gitignore(t *testing.T) {
+	gen := NewGoWorkspaceGenerator()
+	content := gen.gitignoreTemplate()
+
+	if strings.Contains(content, "go.work") {
+		t.Error(".gitignore ignores go.work, which a workspace must commit")
+	}
+	if !strings.Contains(content, "bin/") {
+		t.Error(".gitignore lost the Go defaults")
+	}
+}
+
+func TestGoWorkspaceGenerator_AddModule(t *testing.T) {
+	// newWorkspace generates a workspace in a temp dir and returns its root
+	newWorkspace := func(t *testing.T) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+		if err := NewGoWorkspaceGenerator().Generate("github.com/user/mono"); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		return filepath.Join(tmpDir, "mono")
+	}
+
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(content)
+	}
+
+	t.Run("adds an application", func(t *testing.T) {
+		root := newWorkspace(t)
+
+		if err := NewGoWorkspaceGenerator().AddModule(root, "cli"); err != nil {
+			t.Fatalf("AddModule() failed: %v", err)
+		}
+
+		goMod := readFile(t, filepath.Join(root, "cli", "go.mod"))
+		if !strings.HasPrefix(goMod, "module github.com/user/mono/cli\n") {
+			t.Errorf("cli/go.mod has the wrong module path:\n%s", goMod)
+		}
+		if !strings.Contains(goMod, "replace github.com/user/mono/pkg/shared => ../pkg/shared") {
+			t.Error("cli/go.mod isn't wired to pkg/shared")
+		}
+		if _, err := os.Stat(filepath.Join(root, "cli", "cmd", "cli", "main.go")); err != nil {
+			t.Errorf("cli main.go not created: %v", err)
+		}
+		if !strings.Contains(readFile(t, filepath.Join(root, "go.work")), "\t./cli\n") {
+			t.Error("go.work doesn't use ./cli")
+		}
+	})
+
+	t.Run("adds a library", func(t *testing.T) {
+		root := newWorkspace(t)
+
+		if err := NewGoWorkspaceGenerator().AddModule(root, "pkg/my-store"); err != nil {
+			t.Fatalf("AddModule() failed: %v", err)
+		}
+
+		lib := readFile(t, filepath.Join(root, "pkg", "my-store", "mystore.go"))
+		if !strings.Contains(lib, "package mystore") {
+			t.Errorf("Library has an invalid package name:\n%s", lib)
+		}
+		if !strings.Contains(readFile(t, filepath.Join(root, "go.work")), "\t./pkg/my-store\n") {
+			t.Error("go.work doesn't use ./pkg/my-store")
+		}
+	})
+
+	t.Run("fails for a module already in go.work", func(t *testing.T) {
+		root := newWorkspace(t)
+
+		err := NewGoWorkspaceGenerator().AddModule(root, "./api")
+		if err == nil || !strings.Contains(err.Error(), "already in go.work") {
+			t.Errorf("Expected 'already in go.work' error, got: %v", err)
+		}
+	})
+
+	t.Run("fails outside the workspace", func(t *testing.T) {
+		root := newWorkspace(t)
+
+		err := NewGoWorkspaceGenerator().AddModule(root, "../elsewhere")
+		if err == nil || !strings.Contains(err.Error(), "inside the workspace") {
+			t.Errorf("Expected 'inside the workspace' error, got: %v", err)
+		}
+	})
+
+	t.Run("fails without go.work", func(t *testing.T) {
+		err := NewGoWorkspaceGenerator().AddModule(t.TempDir(), "cli")
+		if err == nil || !strings.Contains(err.Error(), "go.work") {
+			t.Errorf("Expected go.work error, got: %v", err)
+		}
+	})
+}
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"pkg/shared":   "shared",
+		"pkg/my-store": "mystore",
+		"pkg/V2Api":    "v2api",
+		"pkg/9lives":   "lives",
+		"pkg/---":      "lib",
+	}
+
+	for dir, want := range tests {
+		if got := packageName(dir); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", dir, got, want)
+		}
+	}
+}
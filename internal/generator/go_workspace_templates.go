@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// workspaceLibVersion is the placeholder version applications require their
+// workspace libraries at; the replace directive points it at the local copy.
+const workspaceLibVersion = "v0.0.0-00010101000000-000000000000"
+
+func (g *GoWorkspaceGenerator) goWorkTemplate(modules []string) string {
+	sorted := append([]string(nil), modules...)
+	sort.Strings(sorted)
+
+	var uses strings.Builder
+	for _, dir := range sorted {
+		fmt.Fprintf(&uses, "\t./%s\n", dir)
+	}
+
+	return fmt.Sprintf(`go 1.21
+
+use (
+%s)
+`, uses.String())
+}
+
+func (g *GoWorkspaceGenerator) mainGoTemplate(projectName, prefix string, libs []string) string {
+	return g.mainGo(projectName, prefix, libs).render()
+}
+
+// mainGo is the Go generator's main.go, greeting through the workspace
+// libraries. They share the workspace prefix, so they're imported in a
+// group of their own.
+func (g *GoWorkspaceGenerator) mainGo(projectName, prefix string, libs []string) *mainGo {
+	m := g.base.logging.mainGo(projectName)
+	m.module = prefix
+	m.addImport("fmt")
+	m.body = []string{fmt.Sprintf("\tslog.Info(\"Starting %s\")", projectName)}
+	for _, lib := range libs {
+		m.addImport(prefix + "/" + lib)
+		m.body = append(m.body, fmt.Sprintf("\tfmt.Println(%s.Greeting(%q))", packageName(lib), projectName))
+	}
+	if len(libs) == 0 {
+		m.body = append(m.body, fmt.Sprintf("\tfmt.Println(\"Hello from %s!\")", projectName))
+	}
+	return m
+}
+
+// goModTemplate is the Go generator's go.mod, requiring the workspace
+// libraries and replacing them with their local directories
+func (g *GoWorkspaceGenerator) goModTemplate(modulePath, prefix, dir string, libs []string) string {
+	var requires, replaces strings.Builder
+	for _, lib := range libs {
+		rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(lib))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&requires, "\t%s/%s %s\n", prefix, lib, workspaceLibVersion)
+		fmt.Fprintf(&replaces, "replace %s/%s => %s\n", prefix, lib, filepath.ToSlash(rel))
+	}
+
+	goMod := g.base.goModTemplate(modulePath)
+	if requires.Len() == 0 {
+		return goMod
+	}
+	return fmt.Sprintf("%s\nrequire (\n%s)\n\n%s", goMod, requires.String(), replaces.String())
+}
+
+func (g *GoWorkspaceGenerator) libraryGoModTemplate(modulePath string) string {
+	return fmt.Sprintf(`module %s
+
+go 1.21
+`, modulePath)
+}
+
+func (g *GoWorkspaceGenerator) libraryTemplate(name string) string {
+	return fmt.Sprintf(`// Package %[1]s holds code shared by the modules of this workspace.
+package %[1]s
+
+// Greeting returns the greeting printed by the workspace applications.
+func Greeting(name string) string {
+	return "Hello from " + name + "!"
+}
+`, name)
+}
+
+func (g *GoWorkspaceGenerator) libraryTestTemplate(name string) string {
+	return fmt.Sprintf(`package %s
+
+import "testing"
+
+func TestGreeting(t *testing.T) {
+	if got := Greeting("api"); got != "Hello from api!" {
+		t.Errorf("Unexpected greeting: %%q", got)
+	}
+}
+`, name)
+}
+
+// gitignoreTemplate is the Go .gitignore minus go.work, which a workspace
+// repo has to commit.
+func (g *GoWorkspaceGenerator) gitignoreTemplate() string {
+	return strings.Replace(g.base.gitignoreTemplate(), "# Go workspace file\ngo.work\n\n", "", 1)
+}
+
+func (g *GoWorkspaceGenerator) readmeTemplate(projectName string) string {
+	var modules strings.Builder
+	for _, dir := range g.modules {
+		kind := "application"
+		if isLibraryModule(dir) {
+			kind = "library"
+		}
+		fmt.Fprintf(&modules, "- `%s/` - %s\n", dir, kind)
+	}
+
+	return fmt.Sprintf(`# %s
+
+Go workspace created with projectstarter
+
+## Modules
+
+%s
+Applications depend on every library under `+"`pkg/`"+`. `+"`go.work`"+` ties the
+modules together for local development, and each application's `+"`go.mod`"+`
+has matching `+"`replace`"+` directives so it also builds on its own.
+
+## Setup
+
+`+"```bash"+`
+for dir in $(go list -f '{{.Dir}}' -m); do (cd "$dir" && go mod tidy); done
+`+"```"+`
+
+## Adding a module
+
+`+"```bash"+`
+proj workspace add cli          # application
+proj workspace add pkg/storage  # library
+`+"```"+`
+
+## Usage
+
+`+"```bash"+`
+go run ./api/cmd/api
+`+"```"+`
+
+## Testing
+
+`+"`./...`"+` doesn't cross module boundaries, so test every workspace module:
+
+`+"```bash"+`
+go test $(go list -f '{{.Dir}}/...' -m)
+`+"```"+`
+
+## License
+
+MIT
+`, projectName, modules.String())
+}
+
+// tasks work on every module in go.work, so they keep working after
+// "proj workspace add"
+func (g *GoWorkspaceGenerator) tasks() []task {
+	// go list -m lists the workspace modules; "<module>/..." matches their
+	// packages from the workspace root
+	const packages = "$(go list -m | sed 's|$|/...|')"
+
+	dev := "go run ./api/cmd/api"
+	for _, dir := range g.modules {
+		if !isLibraryModule(dir) {
+			dev = fmt.Sprintf("go run ./%s/cmd/%s", dir, path.Base(dir))
+			break
+		}
+	}
+
+	return []task{
+		{name: "dev", desc: "Run the first application", aliases: []string{"d"}, cmds: []string{dev}},
+		{name: "test", desc: "Run the tests of every module", aliases: []string{"t"}, cmds: []string{"go test " + packages}},
+		{
+			name:    "build",
+			desc:    "Build every application into bin/",
+			aliases: []string{"b"},
+			cmds:    []string{"go build -o bin/ $(go list -m | grep -v /pkg/ | sed 's|$|/cmd/...|')"},
+		},
+		{
+			name:    "lint",
+			desc:    "Vet every module and list unformatted files",
+			aliases: []string{"l"},
+			cmds:    []string{"go vet " + packages, goLintCmds[1]},
+		},
+		{name: "clean", desc: "Remove build output", cmds: []string{"rm -rf bin"}},
+	}
+}
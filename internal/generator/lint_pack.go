@@ -0,0 +1,65 @@
+package generator
+
+// lintPack adds a golangci-lint configuration with the linters we keep
+// generated code clean against.
+var lintPack = &pack{
+	name:        "lint",
+	description: "golangci-lint configuration with a curated linter set",
+	kinds:       []string{"go"},
+	files: func(*packTarget) map[string]string {
+		return map[string]string{".golangci.yml": golangciTemplate()}
+	},
+	readme: func(*packTarget) string {
+		return `## Linting
+
+` + "```bash" + `
+golangci-lint run
+` + "```" + `
+
+` + "`.golangci.yml`" + ` enables the standard linters plus a curated set for
+error handling, HTTP bodies and common mistakes, and checks formatting with
+gofmt and goimports.
+`
+	},
+}
+
+func golangciTemplate() string {
+	return `# https://golangci-lint.run/usage/configuration/
+version: "2"
+
+linters:
+  # errcheck, govet, ineffassign, staticcheck and unused
+  default: standard
+  enable:
+    - bodyclose
+    - errorlint
+    - gocritic
+    - misspell
+    - nilerr
+    - revive
+    - unconvert
+    - unparam
+    - usestdlibvars
+  settings:
+    errcheck:
+      exclude-functions:
+        - (io.Closer).Close
+    revive:
+      rules:
+        - name: exported
+          disabled: true
+  exclusions:
+    presets:
+      - std-error-handling
+    rules:
+      - path: _test\.go
+        linters:
+          - errcheck
+          - unparam
+
+formatters:
+  enable:
+    - gofmt
+    - goimports
+`
+}
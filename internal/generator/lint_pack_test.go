@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLintPack(t *testing.T) {
+	files := packedProject(t, "go", "github.com/user/myapp", "lint").files
+
+	t.Run("writes a golangci-lint v2 config", func(t *testing.T) {
+		var config struct {
+			Version string `yaml:"version"`
+			Linters struct {
+				Default string   `yaml:"default"`
+				Enable  []string `yaml:"enable"`
+			} `yaml:"linters"`
+			Formatters struct {
+				Enable []string `yaml:"enable"`
+			} `yaml:"formatters"`
+		}
+		if err := yaml.Unmarshal([]byte(files[".golangci.yml"]), &config); err != nil {
+			t.Fatalf("Invalid .golangci.yml: %v", err)
+		}
+
+		if config.Version != "2" {
+			t.Errorf("Expected config version 2, got %q", config.Version)
+		}
+		if config.Linters.Default != "standard" {
+			t.Errorf("Expected the standard linters, got %q", config.Linters.Default)
+		}
+		if !strings.Contains(strings.Join(config.Linters.Enable, ","), "errorlint") {
+			t.Errorf("Curated linters missing: %v", config.Linters.Enable)
+		}
+		if strings.Join(config.Formatters.Enable, ",") != "gofmt,goimports" {
+			t.Errorf("Unexpected formatters: %v", config.Formatters.Enable)
+		}
+	})
+
+	t.Run("documents golangci-lint in the README", func(t *testing.T) {
+		if !strings.Contains(files["README.md"], "golangci-lint run") {
+			t.Error("README doesn't explain how to lint")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Log formats and level sources accepted by WithLogging.
+var (
+	logFormats      = []string{"tint", "text", "json"}
+	logLevelSources = []string{"flag", "env"}
+)
+
+// tintModule is the handler generated projects log with by default
+const tintModule = "github.com/lmittmann/tint"
+
+// logging is how a generated main.go sets up the default slog logger.
+type logging struct {
+	// format is the handler: "tint" (the default), "text" or "json"
+	format string
+	// levelFrom is where the log level is read from: "flag", "env", or ""
+	// to stay at Info unless a feature pack sets it
+	levelFrom string
+}
+
+// newLogging validates the options and returns the logging setup
+func newLogging(format, levelFrom string) (logging, error) {
+	if format == "" {
+		format = "tint"
+	}
+	if !slices.Contains(logFormats, format) {
+		return logging{}, fmt.Errorf("unknown log format %q (available: %s)", format, strings.Join(logFormats, ", "))
+	}
+	if levelFrom != "" && !slices.Contains(logLevelSources, levelFrom) {
+		return logging{}, fmt.Errorf("unknown log level source %q (available: %s)", levelFrom, strings.Join(logLevelSources, ", "))
+	}
+	return logging{format: format, levelFrom: levelFrom}, nil
+}
+
+// usesTint reports whether the project depends on tint
+func (l logging) usesTint() bool {
+	return l.format == "" || l.format == "tint"
+}
+
+// goRequire is the go.mod require line for the logging setup, if any
+func (l logging) goRequire(versions Catalog) string {
+	if l.usesTint() {
+		return "\nrequire " + tintModule + " " + versions.goModule(tintModule) + "\n"
+	}
+	return ""
+}
+
+// checkPacks rejects packs that set the log level themselves when the level
+// already comes from a flag or env var
+func (l logging) checkPacks(packs []*pack) error {
+	if l.levelFrom != "" && slices.ContainsFunc(packs, func(p *pack) bool { return p == configPack }) {
+		source := map[string]string{"flag": "a -log-level flag", "env": "an environment variable"}[l.levelFrom]
+		return fmt.Errorf("feature pack %q already sets the log level, which can't also come from %s", configPack.name, source)
+	}
+	return nil
+}
+
+// mainGo returns a main.go that sets up logging, for templates to add their
+// own code to. logLevel is a slog.LevelVar packs can set at runtime.
+func (l logging) mainGo(projectName string) *mainGo {
+	m := &mainGo{
+		imports: []string{"log/slog", "os"},
+		decls: []string{`// logLevel is the level of the default logger, Info unless set
+var logLevel = new(slog.LevelVar)`},
+	}
+
+	var handler string
+	switch l.format {
+	case "text":
+		handler = `	// Initialize structured logging as logfmt-style text
+	slog.SetDefault(slog.New(
+		slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}),
+	))`
+	case "json":
+		handler = `	// Initialize structured logging as JSON, one object per line
+	slog.SetDefault(slog.New(
+		slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}),
+	))`
+	default:
+		m.addImport(tintModule)
+		handler = `	// Initialize structured logging with colored output, unless stderr
+	// isn't a terminal or NO_COLOR is set (https://no-color.org)
+	slog.SetDefault(slog.New(
+		tint.NewHandler(os.Stderr, &tint.Options{
+			Level:      logLevel,
+			TimeFormat: "15:04:05.0000",
+			NoColor:    !isTerminal(os.Stderr) || os.Getenv("NO_COLOR") != "",
+			AddSource:  false,
+		}),
+	))`
+	}
+
+	if l.levelFrom == "env" {
+		envVar := envPrefix(projectName) + "LOG_LEVEL"
+		handler += fmt.Sprintf(`
+
+	if level := os.Getenv(%[1]q); level != "" {
+		if err := logLevel.UnmarshalText([]byte(level)); err != nil {
+			slog.Warn("ignoring invalid %[1]s", "error", err)
+		}
+	}`, envVar)
+	}
+	m.decls = append(m.decls, "func init() {\n"+handler+"\n}")
+
+	if l.usesTint() {
+		m.decls = append(m.decls, `// isTerminal reports whether f is a terminal rather than a file or pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}`)
+	}
+
+	if l.levelFrom == "flag" {
+		m.flags = append(m.flags, `	flag.TextVar(logLevel, "log-level", logLevel, "log level: debug, info, warn or error")`)
+	}
+
+	return m
+}
@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewLogging(t *testing.T) {
+	tests := []struct {
+		format, levelFrom string
+		wantErr           string
+	}{
+		{format: "", levelFrom: ""},
+		{format: "tint", levelFrom: "flag"},
+		{format: "text", levelFrom: "env"},
+		{format: "json", levelFrom: ""},
+		{format: "logfmt", wantErr: `unknown log format "logfmt"`},
+		{format: "json", levelFrom: "file", wantErr: `unknown log level source "file"`},
+	}
+
+	for _, tt := range tests {
+		_, err := newLogging(tt.format, tt.levelFrom)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("newLogging(%q, %q) failed: %v", tt.format, tt.levelFrom, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("newLogging(%q, %q): expected error containing %q, got %v", tt.format, tt.levelFrom, tt.wantErr, err)
+		}
+	}
+}
+
+func TestGoGenerator_WithLogging(t *testing.T) {
+	generate := func(t *testing.T, format, levelFrom string) (mainGo, goMod string) {
+		t.Helper()
+		gen := NewGoGenerator()
+		if err := gen.WithLogging(format, levelFrom); err != nil {
+			t.Fatalf("WithLogging() failed: %v", err)
+		}
+		return gen.mainGoTemplate("my-app", "my-app"), gen.goModTemplate("my-app")
+	}
+
+	t.Run("tint turns colour off without a terminal or with NO_COLOR", func(t *testing.T) {
+		mainGo, goMod := generate(t, "tint", "")
+
+		for _, want := range []string{"tint.NewHandler(", `NoColor:    !isTerminal(os.Stderr) || os.Getenv("NO_COLOR") != ""`, "func isTerminal("} {
+			if !strings.Contains(mainGo, want) {
+				t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+			}
+		}
+		if !strings.Contains(goMod, "require github.com/lmittmann/tint "+DefaultCatalog().goModule(tintModule)) {
+			t.Errorf("go.mod doesn't require tint:\n%s", goMod)
+		}
+	})
+
+	for format, handler := range map[string]string{"text": "slog.NewTextHandler(", "json": "slog.NewJSONHandler("} {
+		t.Run(format+" doesn't need tint", func(t *testing.T) {
+			mainGo, goMod := generate(t, format, "")
+
+			if !strings.Contains(mainGo, handler) {
+				t.Errorf("main.go doesn't use %s:\n%s", handler, mainGo)
+			}
+			if strings.Contains(mainGo, "tint") || strings.Contains(goMod, "tint") {
+				t.Errorf("%s logging still uses tint:\n%s\n%s", format, mainGo, goMod)
+			}
+		})
+	}
+
+	t.Run("level from a flag", func(t *testing.T) {
+		mainGo, _ := generate(t, "text", "flag")
+
+		want := `flag.TextVar(logLevel, "log-level", logLevel,`
+		if !strings.Contains(mainGo, want) || !strings.Contains(mainGo, "flag.Parse()") {
+			t.Errorf("main.go doesn't parse %q:\n%s", want, mainGo)
+		}
+	})
+
+	t.Run("level from the environment", func(t *testing.T) {
+		mainGo, _ := generate(t, "json", "env")
+
+		for _, want := range []string{`os.Getenv("MY_APP_LOG_LEVEL")`, "logLevel.UnmarshalText("} {
+			if !strings.Contains(mainGo, want) {
+				t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+			}
+		}
+		if strings.Contains(mainGo, "flag.") {
+			t.Errorf("main.go has flags it doesn't need:\n%s", mainGo)
+		}
+	})
+
+	t.Run("rejects the config pack with a level source", func(t *testing.T) {
+		gen := NewGoGenerator()
+		if err := gen.WithLogging("tint", "flag"); err != nil {
+			t.Fatalf("WithLogging() failed: %v", err)
+		}
+		if err := gen.WithPacks("config"); err != nil {
+			t.Fatalf("WithPacks() failed: %v", err)
+		}
+
+		err := gen.createStructure(t.TempDir(), "my-app")
+		if err == nil || !strings.Contains(err.Error(), "already sets the log level") {
+			t.Errorf("Expected a conflict with the config pack, got %v", err)
+		}
+	})
+}
+
+func TestFullstackGenerator_WithLogging(t *testing.T) {
+	gen := NewFullstackGenerator()
+	if err := gen.WithLogging("json", "flag"); err != nil {
+		t.Fatalf("WithLogging() failed: %v", err)
+	}
+
+	mainGo := gen.mainGoTemplate("shop", "shop")
+	for _, want := range []string{"slog.NewJSONHandler(", `flag.TextVar(logLevel, "log-level"`, `addr := flag.String("addr"`} {
+		if !strings.Contains(mainGo, want) {
+			t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+		}
+	}
+	if goMod := gen.goModTemplate("shop"); strings.Contains(goMod, "tint") {
+		t.Errorf("go.mod requires tint without using it:\n%s", goMod)
+	}
+}
+
+func TestGoDBGenerator_WithLogging(t *testing.T) {
+	gen := NewGoDBGenerator()
+	if err := gen.WithLogging("text", "env"); err != nil {
+		t.Fatalf("WithLogging() failed: %v", err)
+	}
+
+	mainGo := gen.mainGoTemplate("inventory", "inventory")
+	for _, want := range []string{"slog.NewTextHandler(", `os.Getenv("INVENTORY_LOG_LEVEL")`, `dsn := flag.String("db"`} {
+		if !strings.Contains(mainGo, want) {
+			t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+		}
+	}
+	goMod := gen.goModTemplate("inventory")
+	if strings.Contains(goMod, "tint") {
+		t.Errorf("go.mod requires tint without using it:\n%s", goMod)
+	}
+	if !strings.Contains(goMod, "modernc.org/sqlite") {
+		t.Errorf("go.mod doesn't require the SQLite driver:\n%s", goMod)
+	}
+}
+
+func TestGoWorkspaceGenerator_WithLogging(t *testing.T) {
+	gen := NewGoWorkspaceGenerator()
+	if err := gen.WithLogging("json", "flag"); err != nil {
+		t.Fatalf("WithLogging() failed: %v", err)
+	}
+
+	_, files := gen.layout("github.com/user/mono")
+	for _, app := range []string{"api", "worker"} {
+		mainGo := files[filepath.Join(app, "cmd", app, "main.go")]
+		for _, want := range []string{"slog.NewJSONHandler(", `flag.TextVar(logLevel, "log-level"`} {
+			if !strings.Contains(mainGo, want) {
+				t.Errorf("%s main.go doesn't contain %q:\n%s", app, want, mainGo)
+			}
+		}
+		if goMod := files[filepath.Join(app, "go.mod")]; strings.Contains(goMod, "tint") {
+			t.Errorf("%s/go.mod requires tint without using it:\n%s", app, goMod)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"go/format"
+	"slices"
+	"strings"
+)
+
+// mainGo is a generated main.go assembled from parts, so feature packs can
+// add imports, flags and setup to it instead of replacing the file.
+//
+// Code parts are complete, tab-indented Go source: decls at the top level,
+// flags, setup and body inside func main.
+type mainGo struct {
+	// module is the project's module path: its packages are imported in a
+	// group of their own, after third-party ones
+	module  string
+	imports []string
+	// decls are top-level declarations, rendered before func main
+	decls []string
+	// flags declare command-line flags; flag.Parse runs after them
+	flags []string
+	// setup runs after flag parsing, before body
+	setup []string
+	body  []string
+	// shutdown stops what setup started, like buffered exporters. os.Exit
+	// skips deferred calls, so render runs it at the end of main and before
+	// the os.Exit calls of the parts added after it; see addShutdown.
+	shutdown []shutdownStep
+}
+
+// shutdownStep is a shutdown statement and the number of setup parts there
+// were when it was added, which run before what it stops is started
+type shutdownStep struct {
+	code  string
+	setup int
+}
+
+// addShutdown adds a statement stopping what the setup added so far
+// started. Steps run in reverse order, like deferred calls.
+func (m *mainGo) addShutdown(code string) {
+	m.shutdown = append(m.shutdown, shutdownStep{code: code, setup: len(m.setup)})
+}
+
+// addImport adds import paths that aren't imported yet
+func (m *mainGo) addImport(paths ...string) {
+	for _, path := range paths {
+		if !slices.Contains(m.imports, path) {
+			m.imports = append(m.imports, path)
+		}
+	}
+}
+
+// render returns the gofmt-formatted source of main.go
+func (m *mainGo) render() string {
+	imports := slices.Clone(m.imports)
+	if len(m.flags) > 0 && !slices.Contains(imports, "flag") {
+		imports = append(imports, "flag")
+	}
+
+	var b strings.Builder
+	b.WriteString("package main\n\n")
+	b.WriteString(importBlock(imports, m.module))
+
+	for _, decl := range m.decls {
+		b.WriteString(strings.TrimRight(decl, "\n") + "\n\n")
+	}
+
+	var sections []string
+	if len(m.flags) > 0 {
+		sections = append(sections, strings.Join(trimBlocks(m.flags), "\n")+"\n\tflag.Parse()")
+	}
+	for i, part := range trimBlocks(m.setup) {
+		sections = append(sections, m.beforeExit(part, i))
+	}
+	if len(m.body) > 0 {
+		sections = append(sections, m.beforeExit(strings.Join(trimBlocks(m.body), "\n"), len(m.setup)))
+	}
+	if steps := m.shutdownSteps(len(m.setup)); len(steps) > 0 {
+		sections = append(sections, strings.Join(steps, "\n"))
+	}
+
+	b.WriteString("func main() {\n")
+	b.WriteString(strings.Join(sections, "\n\n"))
+	if len(sections) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+
+	src := b.String()
+	if formatted, err := format.Source([]byte(src)); err == nil {
+		return string(formatted)
+	}
+	return src
+}
+
+// shutdownSteps returns the shutdown code for the part after the given
+// number of setup parts, last added first
+func (m *mainGo) shutdownSteps(setup int) []string {
+	var steps []string
+	for i := len(m.shutdown) - 1; i >= 0; i-- {
+		if m.shutdown[i].setup <= setup {
+			steps = append(steps, strings.TrimRight(m.shutdown[i].code, "\n"))
+		}
+	}
+	return steps
+}
+
+// beforeExit inserts the shutdown steps for the part after the given
+// number of setup parts before each os.Exit in it
+func (m *mainGo) beforeExit(part string, setup int) string {
+	steps := m.shutdownSteps(setup)
+	if len(steps) == 0 {
+		return part
+	}
+
+	var lines []string
+	for _, line := range strings.Split(part, "\n") {
+		code := strings.TrimLeft(line, "\t")
+		if strings.HasPrefix(code, "os.Exit(") {
+			// Steps are indented for the body of main, one tab deep
+			indent := line[:len(line)-len(code)-1]
+			for _, step := range steps {
+				for _, stepLine := range strings.Split(step, "\n") {
+					lines = append(lines, indent+stepLine)
+				}
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// importBlock renders an import declaration grouped like goimports with
+// -local module: standard library, third-party, then the module's own
+// packages
+func importBlock(imports []string, module string) string {
+	var std, other, local []string
+	for _, path := range imports {
+		switch {
+		case module != "" && (path == module || strings.HasPrefix(path, module+"/")):
+			local = append(local, path)
+		case strings.Contains(strings.Split(path, "/")[0], "."):
+			other = append(other, path)
+		default:
+			std = append(std, path)
+		}
+	}
+
+	var groups []string
+	for _, group := range [][]string{std, other, local} {
+		if len(group) == 0 {
+			continue
+		}
+		slices.Sort(group)
+		var lines []string
+		for _, path := range group {
+			lines = append(lines, "\t\""+path+"\"\n")
+		}
+		groups = append(groups, strings.Join(lines, ""))
+	}
+	if len(groups) == 0 {
+		return ""
+	}
+	return "import (\n" + strings.Join(groups, "\n") + ")\n\n"
+}
+
+// trimBlocks drops trailing newlines, which render adds back between parts
+func trimBlocks(blocks []string) []string {
+	trimmed := make([]string, len(blocks))
+	for i, block := range blocks {
+		trimmed[i] = strings.TrimRight(block, "\n")
+	}
+	return trimmed
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMainGo_Render(t *testing.T) {
+	t.Run("groups standard library imports first", func(t *testing.T) {
+		m := &mainGo{imports: []string{"github.com/x/y", "os", "fmt"}, body: []string{"\tfmt.Println(os.Args, y.Z)"}}
+
+		want := "import (\n\t\"fmt\"\n\t\"os\"\n\n\t\"github.com/x/y\"\n)\n"
+		if got := m.render(); !strings.Contains(got, want) {
+			t.Errorf("Expected imports %q in:\n%s", want, got)
+		}
+	})
+
+	t.Run("parses flags before setup", func(t *testing.T) {
+		m := &mainGo{
+			flags: []string{"\tv := flag.Bool(\"v\", false, \"verbose\")"},
+			setup: []string{"\tif *v {\n\t\treturn\n\t}"},
+			body:  []string{"\tprintln()"},
+		}
+
+		got := m.render()
+		want := "func main() {\n\tv := flag.Bool(\"v\", false, \"verbose\")\n\tflag.Parse()\n\n\tif *v {\n\t\treturn\n\t}\n\n\tprintln()\n}\n"
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+		if !strings.Contains(got, "\t\"flag\"\n") {
+			t.Error("flag isn't imported")
+		}
+	})
+
+	t.Run("runs shutdown steps before later exits and at the end", func(t *testing.T) {
+		m := &mainGo{setup: []string{"\tif len(os.Args) > 2 {\n\t\tos.Exit(2)\n\t}"}}
+		m.addShutdown("\tprintln(\"a\")")
+		m.addShutdown("\tprintln(\"b\")")
+		m.body = []string{"\tif len(os.Args) > 1 {\n\t\tos.Exit(1)\n\t}"}
+
+		got := m.render()
+		for _, want := range []string{
+			"\tif len(os.Args) > 2 {\n\t\tos.Exit(2)\n\t}\n",
+			"\tif len(os.Args) > 1 {\n\t\tprintln(\"b\")\n\t\tprintln(\"a\")\n\t\tos.Exit(1)\n\t}\n\n\tprintln(\"b\")\n\tprintln(\"a\")\n}\n",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("Expected %q in:\n%s", want, got)
+			}
+		}
+	})
+
+	t.Run("adds imports once", func(t *testing.T) {
+		m := &mainGo{}
+		m.addImport("fmt", "os")
+		m.addImport("fmt")
+
+		if len(m.imports) != 2 {
+			t.Errorf("Expected 2 imports, got %v", m.imports)
+		}
+	})
+}
+
+func TestGoGenerator_MainGoWithoutPacks(t *testing.T) {
+	gen := NewGoGenerator()
+	content := gen.mainGoTemplate("myapp", "myapp")
+
+	for _, want := range []string{
+		"package main\n\nimport (\n\t\"fmt\"\n\t\"log/slog\"\n\t\"os\"\n\n\t\"github.com/lmittmann/tint\"\n)\n\n// logLevel is the level of the default logger, Info unless set\nvar logLevel = new(slog.LevelVar)\n\nfunc init() {",
+		"Level:      logLevel,",
+		"func main() {\n\tslog.Info(\"Starting myapp\")\n\tfmt.Println(\"Hello from myapp!\")\n}\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("main.go doesn't contain %q:\n%s", want, content)
+		}
+	}
+}
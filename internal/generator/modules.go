@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// OfflineModulesError reports a generated Go module whose dependencies
+// couldn't be resolved from the local module cache, leaving go.sum
+// incomplete. The project itself is written: running "go mod tidy" with
+// network access completes it.
+type OfflineModulesError struct {
+	// Dir is the module's directory
+	Dir string
+	// Missing lists the modules or packages not in the module cache, if the
+	// go command named them
+	Missing []string
+	// Output is what go mod tidy printed
+	Output string
+}
+
+func (e *OfflineModulesError) Error() string {
+	if len(e.Missing) > 0 {
+		return fmt.Sprintf("%s: not in the local module cache: %s", e.Dir, strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("%s: go mod tidy failed offline: %s", e.Dir, strings.TrimSpace(e.Output))
+}
+
+// goSumReadme is the README paragraph on go.sum, which tidyOffline writes:
+// go mod tidy is only needed when it couldn't
+const goSumReadme = "`proj` wrote `go.sum` from your module cache, so the project builds as is.\n" +
+	"If it reported dependencies missing from the cache, run `go mod tidy` once\n" +
+	"with network access.\n"
+
+// tidyOffline runs go mod tidy in the module at dir with GOPROXY=off, so
+// go.mod gets its indirect requirements and go.sum is written using only
+// the local module cache. Without a go command there's nothing to do.
+func tidyOffline(dir string) error {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		return nil
+	}
+
+	cmd := exec.Command(goBin, "mod", "tidy")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GOPROXY=off",
+		"GOFLAGS=-mod=mod",
+		// Tidy the module on its own, even inside a workspace
+		"GOWORK=off",
+		// Never download a toolchain either
+		"GOTOOLCHAIN=local",
+	)
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	return &OfflineModulesError{Dir: dir, Missing: missingModules(string(out)), Output: string(out)}
+}
+
+// missingModules extracts what the go command couldn't find from output
+// like "example.com/pkg: module lookup disabled by GOPROXY=off"
+func missingModules(output string) []string {
+	const disabled = ": module lookup disabled by GOPROXY=off"
+
+	var missing []string
+	for _, line := range strings.Split(output, "\n") {
+		i := strings.Index(line, disabled)
+		if i < 0 {
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line[:i]), "go:"))
+		// "go: finding module for package x" errors repeat the package
+		name = strings.TrimPrefix(name, "module ")
+		if name != "" && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// tidyModules runs tidy in every module directory and joins the errors, so
+// one module with missing dependencies doesn't stop the others
+func tidyModules(tidy func(dir string) error, dirs ...string) error {
+	var errs []error
+	for _, dir := range dirs {
+		errs = append(errs, tidy(dir))
+	}
+	return errors.Join(errs...)
+}
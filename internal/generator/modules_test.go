@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMissingModules(t *testing.T) {
+	output := `go: downloading modernc.org/sqlite v1.38.2
+go: db/internal/db imports
+	modernc.org/sqlite: module lookup disabled by GOPROXY=off
+go: example.com/dep@v1.2.3: module lookup disabled by GOPROXY=off
+go: finding module for package example.com/other
+go: db/internal/store imports
+	modernc.org/sqlite: module lookup disabled by GOPROXY=off
+`
+	want := []string{"modernc.org/sqlite", "example.com/dep@v1.2.3"}
+	if got := missingModules(output); !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestTidyOffline(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not found in PATH")
+	}
+
+	writeModule := func(t *testing.T, goMod, mainGo string) string {
+		t.Helper()
+		dir := t.TempDir()
+		files := map[string]string{"go.mod": goMod, "main.go": mainGo}
+		if err := writeProject(dir, nil, files); err != nil {
+			t.Fatalf("writeProject() failed: %v", err)
+		}
+		return dir
+	}
+
+	t.Run("succeeds for a module without dependencies", func(t *testing.T) {
+		dir := writeModule(t, "module example.com/app\n\ngo 1.21\n", "package main\n\nfunc main() {}\n")
+
+		if err := tidyOffline(dir); err != nil {
+			t.Fatalf("tidyOffline() failed: %v", err)
+		}
+	})
+
+	t.Run("writes go.sum from the module cache", func(t *testing.T) {
+		gen := NewGoGenerator()
+		_, files := gen.layout("example.com/app")
+		dir := t.TempDir()
+		if err := writeProject(dir, nil, files); err != nil {
+			t.Fatalf("writeProject() failed: %v", err)
+		}
+
+		err := tidyOffline(dir)
+		var offline *OfflineModulesError
+		if errors.As(err, &offline) {
+			t.Skipf("tint isn't in the module cache: %v", err)
+		}
+		if err != nil {
+			t.Fatalf("tidyOffline() failed: %v", err)
+		}
+
+		goSum, err := os.ReadFile(filepath.Join(dir, "go.sum"))
+		if err != nil {
+			t.Fatalf("go.sum not written: %v", err)
+		}
+		if !strings.Contains(string(goSum), "github.com/lmittmann/tint "+DefaultCatalog().goModule(tintModule)+" h1:") {
+			t.Errorf("go.sum doesn't have tint:\n%s", goSum)
+		}
+	})
+
+	t.Run("reports modules missing from the cache", func(t *testing.T) {
+		dir := writeModule(t,
+			"module example.com/app\n\ngo 1.21\n\nrequire example.invalid/missing v1.0.0\n",
+			"package main\n\nimport _ \"example.invalid/missing\"\n\nfunc main() {}\n",
+		)
+
+		err := tidyOffline(dir)
+		var offline *OfflineModulesError
+		if !errors.As(err, &offline) {
+			t.Fatalf("Expected an OfflineModulesError, got %v", err)
+		}
+		if offline.Dir != dir || !strings.Contains(offline.Error(), "example.invalid/missing") {
+			t.Errorf("Expected the missing module in the error, got %q", offline.Error())
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.sum")); err == nil {
+			t.Error("Expected no go.sum when dependencies are missing")
+		}
+	})
+}
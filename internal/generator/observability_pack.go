@@ -0,0 +1,529 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/mod/module"
+)
+
+// observabilityPack instruments an HTTP service: Prometheus metrics on
+// /metrics, OpenTelemetry traces printed to stdout, trace IDs in log records
+// and an opt-in pprof listener. Nothing needs a collector to run or test.
+var observabilityPack = &pack{
+	name:        "observability",
+	description: "Prometheus /metrics, OpenTelemetry tracing, trace IDs in logs, --pprof",
+	kinds:       []string{"fullstack"},
+	// The configured log level applies before tracing and pprof start, and
+	// invalid configuration exits before they do
+	after: []string{"config"},
+	files: func(t *packTarget) map[string]string {
+		dir := filepath.Join("internal", "observability")
+		return map[string]string{
+			filepath.Join(dir, "observability.go"):                       observabilityGoTemplate(),
+			filepath.Join(dir, "metrics.go"):                             metricsGoTemplate(),
+			filepath.Join(dir, "tracing.go"):                             tracingGoTemplate(),
+			filepath.Join(dir, "log.go"):                                 traceLogGoTemplate(),
+			filepath.Join(dir, "pprof.go"):                               pprofGoTemplate(),
+			filepath.Join(dir, "observability_test.go"):                  observabilityTestTemplate(),
+			filepath.Join("internal", "server", "observability_test.go"): serverObservabilityTestTemplate(t.modulePath),
+		}
+	},
+	readme: observabilityReadmeTemplate,
+	goRequires: []module.Version{
+		{Path: "github.com/prometheus/client_golang"},
+		{Path: "go.opentelemetry.io/otel"},
+		{Path: "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"},
+		{Path: "go.opentelemetry.io/otel/sdk"},
+		{Path: "go.opentelemetry.io/otel/trace"},
+	},
+	// OpenTelemetry needs go 1.23, which also sets http.Request.Pattern
+	goVersion: "1.23.0",
+	main: func(t *packTarget, m *mainGo) {
+		m.addImport("context", "io", "log/slog", "net/http", "os", t.modulePath+"/internal/observability")
+		m.flags = append(m.flags,
+			`	traces := flag.Bool("traces", true, "print OpenTelemetry traces to stdout")`,
+			`	pprofAddr := flag.String("pprof", "", "serve net/http/pprof on this address, e.g. localhost:6060")`,
+		)
+		m.setup = append(m.setup, `	// Log records made with a request's context carry its trace ID
+	slog.SetDefault(slog.New(observability.LogHandler(slog.Default().Handler())))
+
+	var traceOut io.Writer
+	if *traces {
+		traceOut = os.Stdout
+	}
+	shutdownTracing, err := observability.SetupTracing(`+fmt.Sprintf("%q", t.shortName)+`, traceOut)
+	if err != nil {
+		slog.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}`)
+		// Flushes the spans the batcher still holds
+		m.addShutdown(`	if err := shutdownTracing(context.Background()); err != nil {
+		slog.Error("failed to flush traces", "error", err)
+	}`)
+		m.setup = append(m.setup, `	if *pprofAddr != "" {
+		go func() {
+			slog.Info("Serving pprof", "addr", *pprofAddr)
+			if err := http.ListenAndServe(*pprofAddr, observability.PprofHandler()); err != nil {
+				slog.Error("pprof server failed", "error", err)
+			}
+		}()
+	}`)
+	},
+	server: func(t *packTarget, s *serverGo) {
+		s.addImport(t.modulePath + "/internal/observability")
+		s.routes = append(s.routes, `	mux.Handle("GET /metrics", observability.MetricsHandler())`)
+		// Trace runs first so Metrics and Logger see the span's context, and
+		// both run before Recover to see panics as 500s
+		s.middleware = append(s.middleware, "observability.Trace", "observability.Metrics")
+	},
+}
+
+func observabilityGoTemplate() string {
+	return `// Package observability instruments the HTTP server: Prometheus metrics,
+// OpenTelemetry traces, trace IDs in log records and pprof profiles.
+package observability
+
+import "net/http"
+
+// statusRecorder remembers the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// route names the request's route for metrics and spans: the ServeMux
+// pattern it matched, e.g. "GET /api/hello". Using the pattern rather than
+// the path keeps label values bounded.
+func route(r *http.Request) string {
+	if r.Pattern == "" {
+		return "unmatched"
+	}
+	return r.Pattern
+}
+`
+}
+
+func metricsGoTemplate() string {
+	return `package observability
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// registry holds the service's metrics. Unlike the default registry, it
+// only exports what is registered here.
+var registry = prometheus.NewRegistry()
+
+var (
+	requests = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	duration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Metrics counts requests and records their latency.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		// The mux sets r.Pattern while routing, so it's known by now
+		requests.WithLabelValues(route(r), r.Method, strconv.Itoa(rec.status)).Inc()
+		duration.WithLabelValues(route(r), r.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// MetricsHandler serves the metrics in the Prometheus text format.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+`
+}
+
+func tracingGoTemplate() string {
+	return `package observability
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SetupTracing installs the global tracer provider and the W3C trace
+// context propagator. Spans are printed to w as JSON; with a nil w they are
+// still recorded, so logs carry trace IDs, but not exported. To send traces
+// to a collector, replace the stdout exporter with an OTLP one.
+//
+// Call shutdown before exiting to flush buffered spans.
+func SetupTracing(serviceName string, w io.Writer) (shutdown func(context.Context) error, err error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	}
+	if w != nil {
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Trace starts a server span for each request, continuing the trace of an
+// incoming traceparent header. Handlers get the span from r.Context().
+func Trace(next http.Handler) http.Handler {
+	tracer := otel.Tracer("observability")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rec, r)
+
+		span.SetName(route(r))
+		span.SetAttributes(
+			attribute.String("http.route", route(r)),
+			attribute.Int("http.response.status_code", rec.status),
+		)
+		if rec.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.status))
+		}
+	})
+}
+`
+}
+
+func traceLogGoTemplate() string {
+	return `package observability
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogHandler wraps h so that records logged with a context inside a span,
+// like slog.InfoContext(r.Context(), ...), carry trace_id and span_id.
+func LogHandler(h slog.Handler) slog.Handler {
+	return traceHandler{h}
+}
+
+type traceHandler struct {
+	slog.Handler
+}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r = r.Clone()
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
+`
+}
+
+func pprofGoTemplate() string {
+	return `package observability
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// PprofHandler serves the runtime profiles under /debug/pprof/. Serve it on
+// a separate, private address: profiles expose internals and cost CPU.
+func PprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+`
+}
+
+func observabilityTestTemplate() string {
+	return `package observability
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// testMux routes like the server: handlers registered with method patterns
+func testMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /hello/{name}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})
+	return mux
+}
+
+// recordSpans installs a tracer provider that keeps spans in memory
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return exporter
+}
+
+func TestMetrics(t *testing.T) {
+	handler := Metrics(testMux())
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/hello/gopher", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/missing", nil))
+
+	rec := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		` + "`" + `http_requests_total{code="200",method="GET",route="GET /hello/{name}"}` + "`" + `,
+		` + "`" + `http_requests_total{code="404",method="GET",route="unmatched"}` + "`" + `,
+		` + "`" + `http_request_duration_seconds_count{method="GET",route="GET /hello/{name}"}` + "`" + `,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected /metrics to contain %q", want)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	exporter := recordSpans(t)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest("GET", "/hello/gopher", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	Trace(testMux()).ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "GET /hello/{name}" {
+		t.Errorf("Expected span named after the route, got %q", spans[0].Name)
+	}
+	if got := spans[0].SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("Expected the incoming trace %s to continue, got %s", traceID, got)
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	recordSpans(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(LogHandler(slog.NewJSONHandler(&buf, nil)))
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "work")
+	logger.InfoContext(ctx, "inside span")
+	span.End()
+	logger.Info("outside span")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d", len(lines))
+	}
+
+	var inside, outside map[string]any
+	json.Unmarshal([]byte(lines[0]), &inside)
+	json.Unmarshal([]byte(lines[1]), &outside)
+
+	if inside["trace_id"] != span.SpanContext().TraceID().String() {
+		t.Errorf("Expected trace_id %s, got %v", span.SpanContext().TraceID(), inside["trace_id"])
+	}
+	if inside["span_id"] != span.SpanContext().SpanID().String() {
+		t.Errorf("Expected span_id %s, got %v", span.SpanContext().SpanID(), inside["span_id"])
+	}
+	if _, ok := outside["trace_id"]; ok {
+		t.Errorf("Expected no trace_id outside a span, got %v", outside["trace_id"])
+	}
+}
+
+func TestSetupTracingPrintsSpans(t *testing.T) {
+	var buf bytes.Buffer
+	shutdown, err := SetupTracing("test", &buf)
+	if err != nil {
+		t.Fatalf("SetupTracing() failed: %v", err)
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "exported-span")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "exported-span") {
+		t.Errorf("Expected the span in the stdout exporter's output, got %q", buf.String())
+	}
+}
+
+func TestPprofHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	PprofHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/pprof/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "goroutine") {
+		t.Errorf("Expected the profile index, got %q", rec.Body.String())
+	}
+}
+`
+}
+
+// serverObservabilityTestTemplate tests the server's middleware chain: a
+// panic recovered as a 500 must still show up in traces and metrics
+func serverObservabilityTestTemplate(modulePath string) string {
+	return fmt.Sprintf(`package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"%s/internal/observability"
+)
+
+func TestPanicsAreTracedAndCounted(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	Chain(mux, middleware...).ServeHTTP(rec, httptest.NewRequest("GET", "/panic", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, got %%d", rec.Code)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %%d", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("Expected an error span, got status %%v", spans[0].Status.Code)
+	}
+
+	metrics := httptest.NewRecorder()
+	observability.MetricsHandler().ServeHTTP(metrics, httptest.NewRequest("GET", "/metrics", nil))
+	want := `+"`"+`http_requests_total{code="500",method="GET",route="GET /panic"}`+"`"+`
+	if !strings.Contains(metrics.Body.String(), want) {
+		t.Errorf("Expected /metrics to contain %%q", want)
+	}
+}
+`, modulePath)
+}
+
+func observabilityReadmeTemplate(t *packTarget) string {
+	return fmt.Sprintf(`## Observability
+
+`+"`internal/observability`"+` instruments the server through its middleware chain:
+
+- `+"`GET /metrics`"+` serves Prometheus metrics: `+"`http_requests_total`"+` and
+  `+"`http_request_duration_seconds`"+` by route, plus Go runtime metrics
+- every request gets an OpenTelemetry span, continuing incoming
+  `+"`traceparent`"+` headers; spans are printed to stdout (`+"`-traces=false`"+` turns
+  that off, swap in an OTLP exporter in `+"`tracing.go`"+` to use a collector)
+- log records made with the request context carry `+"`trace_id`"+` and `+"`span_id`"+`
+
+`+"```bash"+`
+go run ./cmd/%[1]s -pprof localhost:6060
+go tool pprof http://localhost:6060/debug/pprof/profile
+`+"```"+`
+
+The tests use an in-memory exporter and need no collector.
+`, t.shortName)
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestObservabilityPack_Server(t *testing.T) {
+	target := packedProject(t, "fullstack", "github.com/user/shop", "observability")
+	serverGo := target.files[filepath.Join("internal", "server", "server.go")]
+
+	for _, want := range []string{
+		"\t\"github.com/user/shop/internal/observability\"\n",
+		`mux.Handle("GET /metrics", observability.MetricsHandler())`,
+		"[]Middleware{observability.Trace, observability.Metrics, Recover, Logger}",
+	} {
+		if !strings.Contains(serverGo, want) {
+			t.Errorf("server.go doesn't contain %q:\n%s", want, serverGo)
+		}
+	}
+}
+
+func TestObservabilityPack_Main(t *testing.T) {
+	target := packedProject(t, "fullstack", "github.com/user/shop", "observability")
+	mainGo := target.files[filepath.Join("cmd", "shop", "main.go")]
+
+	for _, want := range []string{
+		`pprofAddr := flag.String("pprof", ""`,
+		`traces := flag.Bool("traces", true`,
+		"observability.LogHandler(slog.Default().Handler())",
+		`observability.SetupTracing("shop", traceOut)`,
+		"handler := server.New(web.Dist())",
+	} {
+		if !strings.Contains(mainGo, want) {
+			t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+		}
+	}
+}
+
+func TestObservabilityPack_ShutdownTracing(t *testing.T) {
+	const flush = "if err := shutdownTracing(context.Background()); err != nil {"
+
+	t.Run("flushes spans when the server stops or fails", func(t *testing.T) {
+		target := packedProject(t, "fullstack", "github.com/user/shop", "observability")
+		mainGo := target.files[filepath.Join("cmd", "shop", "main.go")]
+
+		if strings.Contains(mainGo, "defer shutdownTracing") {
+			t.Errorf("main.go defers shutdownTracing, which os.Exit skips:\n%s", mainGo)
+		}
+		for _, want := range []string{
+			"\t\t" + flush + "\n\t\t\tslog.Error(\"failed to flush traces\", \"error\", err)\n\t\t}\n\t\tos.Exit(1)\n",
+			"srv.Shutdown(shutdownCtx)",
+		} {
+			if !strings.Contains(mainGo, want) {
+				t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+			}
+		}
+		if !strings.HasSuffix(mainGo, "\t"+flush+"\n\t\tslog.Error(\"failed to flush traces\", \"error\", err)\n\t}\n}\n") {
+			t.Errorf("main.go doesn't flush spans before returning:\n%s", mainGo)
+		}
+	})
+}
+
+func TestObservabilityPack_WithConfig(t *testing.T) {
+	for _, names := range [][]string{{"config", "observability"}, {"observability", "config"}} {
+		t.Run(strings.Join(names, ","), func(t *testing.T) {
+			mainGo := packedProject(t, "fullstack", "github.com/user/shop", names...).files[filepath.Join("cmd", "shop", "main.go")]
+
+			// The config sets the log level and can exit, so it comes first
+			levelSet := strings.Index(mainGo, "logLevel.Set(cfg.LogLevel)")
+			if levelSet < 0 {
+				t.Fatalf("main.go doesn't set the configured log level:\n%s", mainGo)
+			}
+			for _, later := range []string{"observability.SetupTracing(", `slog.Info("Serving pprof"`} {
+				if i := strings.Index(mainGo, later); i < levelSet {
+					t.Errorf("main.go runs %s before loading the config:\n%s", later, mainGo)
+				}
+			}
+		})
+	}
+}
+
+func TestObservabilityPack_GoMod(t *testing.T) {
+	target := packedProject(t, "fullstack", "github.com/user/shop", "observability")
+	goMod := target.files["go.mod"]
+
+	for _, want := range []string{
+		"go 1.23.0\n",
+		"github.com/prometheus/client_golang v1.23.2",
+		"go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.38.0",
+	} {
+		if !strings.Contains(goMod, want) {
+			t.Errorf("go.mod doesn't contain %q:\n%s", want, goMod)
+		}
+	}
+}
+
+func TestObservabilityPack_Files(t *testing.T) {
+	target := packedProject(t, "fullstack", "github.com/user/shop", "observability")
+
+	for _, name := range []string{"observability.go", "metrics.go", "tracing.go", "log.go", "pprof.go", "observability_test.go"} {
+		if _, ok := target.files[filepath.Join("internal", "observability", name)]; !ok {
+			t.Errorf("%s not added", name)
+		}
+	}
+	if _, ok := target.files[filepath.Join("internal", "server", "observability_test.go")]; !ok {
+		t.Error("server tests of the middleware chain not added")
+	}
+	if !strings.Contains(target.files["README.md"], "## Observability") {
+		t.Error("README doesn't document observability")
+	}
+}
@@ -0,0 +1,379 @@
+package generator
+
+import (
+	"fmt"
+	"go/version"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// pack is an optional feature layered on top of a generated project, so that
+// combinations like docker + ci + lint don't each need their own template.
+// Everything a pack does is declared here and applied by applyPacks.
+type pack struct {
+	name        string
+	description string
+	// kinds are the project types the pack supports, named like the
+	// "proj start" subcommands
+	kinds []string
+	// requires lists packs that are applied first, added automatically
+	requires []string
+	// after lists packs that are applied first when they're selected too,
+	// so their main.go setup runs before this pack's
+	after []string
+	// conflicts lists packs that can't be combined with this one
+	conflicts []string
+
+	// files returns the files the pack adds, relative to the project root.
+	// A pack can't replace files of the project or of other packs.
+	files func(t *packTarget) map[string]string
+	// finalFiles are added like files, but once every pack is applied, for
+	// files derived from what other packs add
+	finalFiles func(t *packTarget) map[string]string
+	// readme returns README sections, inserted before "## License"
+	readme func(t *packTarget) string
+	// gitignore entries are appended to .gitignore under the pack's name
+	gitignore []string
+	// goRequires are added to the require block of go.mod, at the version
+	// catalog's version when Version is empty
+	goRequires []module.Version
+	// goVersion is the minimum go directive goRequires need; older go.mod
+	// files are raised to it before any pack is applied
+	goVersion string
+	// main adds to the project's main.go
+	main func(t *packTarget, m *mainGo)
+	// server adds routes and middleware to an HTTP service's server.go
+	server func(t *packTarget, s *serverGo)
+}
+
+// packTarget is the project a pack is applied to.
+type packTarget struct {
+	kind string
+	// modulePath is empty for projects without a go.mod
+	modulePath string
+	shortName  string
+	// files is the project layout, edited in place
+	files map[string]string
+	// main, when set, is rendered to mainPath once the packs are applied
+	main     *mainGo
+	mainPath string
+	// server, when set, is rendered to serverPath like main
+	server     *serverGo
+	serverPath string
+	// versions are the dependency versions to use, the embedded catalog's
+	// when nil
+	versions *Catalog
+}
+
+// catalog returns the dependency versions the project is generated with
+func (t *packTarget) catalog() Catalog {
+	if t.versions == nil {
+		return DefaultCatalog()
+	}
+	return *t.versions
+}
+
+// builtinPacks are the feature packs available to "--with", in the order
+// they're listed in help output.
+var builtinPacks = append([]*pack{
+	dockerPack,
+	lintPack,
+	releasePack,
+	devcontainerPack,
+	observabilityPack,
+	configPack,
+}, ciPacks()...)
+
+// PackInfo describes a feature pack for help output.
+type PackInfo struct {
+	Name        string
+	Description string
+}
+
+// Packs lists the feature packs available for projects of the given kind.
+func Packs(kind string) []PackInfo {
+	var infos []PackInfo
+	for _, p := range builtinPacks {
+		if slices.Contains(p.kinds, kind) {
+			infos = append(infos, PackInfo{Name: p.name, Description: p.description})
+		}
+	}
+	return infos
+}
+
+// resolvePacks looks up names in registry for a project of the given kind.
+// Dependencies are added and ordered before the packs that need them, and
+// conflicting combinations are rejected.
+func resolvePacks(registry []*pack, kind string, names []string) ([]*pack, error) {
+	byName := make(map[string]*pack, len(registry))
+	for _, p := range registry {
+		byName[p.name] = p
+	}
+
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[strings.TrimSpace(name)] = true
+	}
+
+	var resolved []*pack
+	state := map[string]int{} // 1 while visiting, 2 once resolved
+
+	var visit func(name, requiredBy string) error
+	visit = func(name, requiredBy string) error {
+		p, ok := byName[name]
+		if !ok {
+			if requiredBy != "" {
+				return fmt.Errorf("feature pack %q requires unknown pack %q", requiredBy, name)
+			}
+			return fmt.Errorf("unknown feature pack %q (available: %s)", name, availablePacks(registry, kind))
+		}
+		if !slices.Contains(p.kinds, kind) {
+			return fmt.Errorf("feature pack %q doesn't support %s projects", name, kind)
+		}
+
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("feature pack %q depends on itself", name)
+		case 2:
+			return nil
+		}
+
+		state[name] = 1
+		for _, dep := range p.requires {
+			if err := visit(dep, name); err != nil {
+				return err
+			}
+		}
+		for _, dep := range p.after {
+			if !selected[dep] {
+				continue
+			}
+			if err := visit(dep, name); err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		resolved = append(resolved, p)
+		return nil
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if err := visit(name, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, a := range resolved {
+		for _, b := range resolved[i+1:] {
+			if slices.Contains(a.conflicts, b.name) || slices.Contains(b.conflicts, a.name) {
+				return nil, fmt.Errorf("feature packs %q and %q can't be combined", a.name, b.name)
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+// availablePacks lists the names of the packs supporting kind
+func availablePacks(registry []*pack, kind string) string {
+	var names []string
+	for _, p := range registry {
+		if slices.Contains(p.kinds, kind) {
+			names = append(names, p.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// applyPacks layers packs, as returned by resolvePacks, onto t.files
+func applyPacks(t *packTarget, packs []*pack) error {
+	editedMain, editedServer := false, false
+
+	// Raise the go directive first, so every pack sees the final version
+	for _, p := range packs {
+		if p.goVersion == "" {
+			continue
+		}
+		goMod, err := raiseGoVersion(t.files["go.mod"], p.goVersion)
+		if err != nil {
+			return fmt.Errorf("feature pack %q: %w", p.name, err)
+		}
+		t.files["go.mod"] = goMod
+	}
+
+	for _, p := range packs {
+		if p.files != nil {
+			if err := addPackFiles(t, p, p.files(t)); err != nil {
+				return err
+			}
+		}
+
+		if p.readme != nil {
+			t.files["README.md"] = insertReadmeSection(t.files["README.md"], p.readme(t))
+		}
+
+		if len(p.gitignore) > 0 {
+			t.files[".gitignore"] = appendGitignore(t.files[".gitignore"], p.name, p.gitignore)
+		}
+
+		if len(p.goRequires) > 0 {
+			requires := slices.Clone(p.goRequires)
+			for i, req := range requires {
+				if req.Version == "" {
+					requires[i].Version = t.catalog().goModule(req.Path)
+				}
+			}
+			goMod, err := addGoRequires(t.files["go.mod"], requires)
+			if err != nil {
+				return fmt.Errorf("feature pack %q: %w", p.name, err)
+			}
+			t.files["go.mod"] = goMod
+		}
+
+		if p.main != nil {
+			if t.main == nil {
+				return fmt.Errorf("feature pack %q: project has no main.go to extend", p.name)
+			}
+			p.main(t, t.main)
+			editedMain = true
+		}
+
+		if p.server != nil {
+			if t.server == nil {
+				return fmt.Errorf("feature pack %q: project has no HTTP server to extend", p.name)
+			}
+			p.server(t, t.server)
+			editedServer = true
+		}
+	}
+
+	if editedMain {
+		t.files[t.mainPath] = t.main.render()
+	}
+	if editedServer {
+		t.files[t.serverPath] = t.server.render()
+	}
+
+	for _, p := range packs {
+		if p.finalFiles != nil {
+			if err := addPackFiles(t, p, p.finalFiles(t)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addPackFiles adds the files of pack p to the target, refusing to replace
+// existing ones
+func addPackFiles(t *packTarget, p *pack, added map[string]string) error {
+	names := make([]string, 0, len(added))
+	for name := range added {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, exists := t.files[name]; exists {
+			return fmt.Errorf("feature pack %q: %s already exists", p.name, name)
+		}
+		t.files[name] = added[name]
+	}
+	return nil
+}
+
+// insertReadmeSection puts section before the "## License" heading, or at
+// the end when there is none
+func insertReadmeSection(readme, section string) string {
+	section = strings.TrimRight(section, "\n") + "\n\n"
+
+	if i := strings.Index(readme, "\n## License"); i >= 0 {
+		return readme[:i+1] + section + readme[i+1:]
+	}
+	return strings.TrimRight(readme, "\n") + "\n\n" + section
+}
+
+// appendGitignore adds the entries not already in gitignore as a new
+// commented group
+func appendGitignore(gitignore, title string, entries []string) string {
+	existing := map[string]bool{}
+	for _, line := range strings.Split(gitignore, "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	var missing []string
+	for _, entry := range entries {
+		if !existing[entry] {
+			missing = append(missing, entry)
+		}
+	}
+	if len(missing) == 0 {
+		return gitignore
+	}
+
+	return strings.TrimRight(gitignore, "\n") + "\n\n# " + title + "\n" + strings.Join(missing, "\n") + "\n"
+}
+
+// addGoRequires adds module requirements to the go.mod content
+func addGoRequires(goMod string, requires []module.Version) (string, error) {
+	f, err := modfile.Parse("go.mod", []byte(goMod), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+
+	for _, req := range requires {
+		if err := f.AddRequire(req.Path, req.Version); err != nil {
+			return "", fmt.Errorf("failed to require %s: %w", req.Path, err)
+		}
+	}
+	f.Cleanup()
+
+	out, err := f.Format()
+	if err != nil {
+		return "", fmt.Errorf("failed to format go.mod: %w", err)
+	}
+	return string(out), nil
+}
+
+// raiseGoVersion sets the go directive of the go.mod content to minVersion
+// unless it already is at least that
+func raiseGoVersion(goMod, minVersion string) (string, error) {
+	f, err := modfile.Parse("go.mod", []byte(goMod), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	if f.Go != nil && version.Compare("go"+f.Go.Version, "go"+minVersion) >= 0 {
+		return goMod, nil
+	}
+
+	if err := f.AddGoStmt(minVersion); err != nil {
+		return "", fmt.Errorf("failed to set go %s: %w", minVersion, err)
+	}
+	out, err := f.Format()
+	if err != nil {
+		return "", fmt.Errorf("failed to format go.mod: %w", err)
+	}
+	return string(out), nil
+}
+
+// goVersion returns the go directive of the target's go.mod, so packs build
+// with the same Go version the project declares
+func (t *packTarget) goVersion() string {
+	f, err := modfile.ParseLax("go.mod", []byte(t.files["go.mod"]), nil)
+	if err != nil || f.Go == nil {
+		return ""
+	}
+	return f.Go.Version
+}
@@ -0,0 +1,340 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/module"
+)
+
+// testPacks is a registry for exercising the pack machinery without
+// depending on the built-in packs.
+func testPacks() []*pack {
+	return []*pack{
+		{name: "base", kinds: []string{"go"}},
+		{name: "needs-base", kinds: []string{"go"}, requires: []string{"base"}},
+		{name: "needs-needs-base", kinds: []string{"go"}, requires: []string{"needs-base"}},
+		{name: "hates-base", kinds: []string{"go"}, conflicts: []string{"base"}},
+		{name: "elm-only", kinds: []string{"vite-elm"}},
+		{name: "loop-a", kinds: []string{"go"}, requires: []string{"loop-b"}},
+		{name: "loop-b", kinds: []string{"go"}, requires: []string{"loop-a"}},
+		{name: "broken", kinds: []string{"go"}, requires: []string{"missing"}},
+		{name: "after-base", kinds: []string{"go"}, after: []string{"base"}},
+	}
+}
+
+func packNames(packs []*pack) string {
+	names := make([]string, len(packs))
+	for i, p := range packs {
+		names[i] = p.name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestResolvePacks(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		input   []string
+		want    string
+		wantErr string
+	}{
+		{name: "no packs", kind: "go", input: nil, want: ""},
+		{name: "single pack", kind: "go", input: []string{"base"}, want: "base"},
+		{name: "adds dependencies first", kind: "go", input: []string{"needs-needs-base"}, want: "base,needs-base,needs-needs-base"},
+		{name: "deduplicates", kind: "go", input: []string{"base", "needs-base", " base "}, want: "base,needs-base"},
+		{name: "orders after selected packs", kind: "go", input: []string{"after-base", "base"}, want: "base,after-base"},
+		{name: "doesn't add packs it comes after", kind: "go", input: []string{"after-base"}, want: "after-base"},
+		{name: "skips empty names", kind: "go", input: []string{"", "base"}, want: "base"},
+		{name: "unknown pack", kind: "go", input: []string{"nope"}, wantErr: `unknown feature pack "nope"`},
+		{name: "lists available packs", kind: "vite-elm", input: []string{"nope"}, wantErr: "(available: elm-only)"},
+		{name: "unsupported kind", kind: "go", input: []string{"elm-only"}, wantErr: "doesn't support go projects"},
+		{name: "conflict", kind: "go", input: []string{"hates-base", "base"}, wantErr: "can't be combined"},
+		{name: "conflict through dependency", kind: "go", input: []string{"needs-base", "hates-base"}, wantErr: "can't be combined"},
+		{name: "dependency cycle", kind: "go", input: []string{"loop-a"}, wantErr: "depends on itself"},
+		{name: "unknown dependency", kind: "go", input: []string{"broken"}, wantErr: `requires unknown pack "missing"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packs, err := resolvePacks(testPacks(), tt.kind, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePacks() failed: %v", err)
+			}
+			if got := packNames(packs); got != tt.want {
+				t.Errorf("Expected packs %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// packedProject generates a project of the given kind and applies the named
+// packs to it, the way the generator of that kind does
+func packedProject(t *testing.T, kind, modulePath string, names ...string) *packTarget {
+	t.Helper()
+
+	packs, err := resolvePacks(builtinPacks, kind, names)
+	if err != nil {
+		t.Fatalf("resolvePacks() failed: %v", err)
+	}
+
+	var target *packTarget
+	switch kind {
+	case "go":
+		gen := NewGoGenerator()
+		_, files := gen.layout(modulePath)
+		target = gen.packTarget(modulePath, files)
+	case "fullstack":
+		gen := NewFullstackGenerator()
+		_, files := gen.layout(modulePath)
+		target = gen.packTarget(modulePath, files)
+	case "vite-elm":
+		gen := NewViteElmGenerator()
+		_, files := gen.layout(modulePath)
+		target = gen.packTarget(modulePath, files)
+	default:
+		t.Fatalf("No generator for %s projects", kind)
+	}
+
+	if err := applyPacks(target, packs); err != nil {
+		t.Fatalf("applyPacks() failed: %v", err)
+	}
+	return target
+}
+
+func TestApplyPacks(t *testing.T) {
+	newTarget := func() *packTarget {
+		_, files := NewGoGenerator().layout("github.com/user/myapp")
+		return &packTarget{kind: "go", modulePath: "github.com/user/myapp", shortName: "myapp", files: files}
+	}
+
+	t.Run("adds files", func(t *testing.T) {
+		target := newTarget()
+		p := &pack{name: "extra", files: func(t *packTarget) map[string]string {
+			return map[string]string{"extra/" + t.shortName + ".txt": "hello"}
+		}}
+
+		if err := applyPacks(target, []*pack{p}); err != nil {
+			t.Fatalf("applyPacks() failed: %v", err)
+		}
+		if target.files["extra/myapp.txt"] != "hello" {
+			t.Error("Pack file not added")
+		}
+	})
+
+	t.Run("refuses to replace files", func(t *testing.T) {
+		target := newTarget()
+		p := &pack{name: "clobber", files: func(*packTarget) map[string]string {
+			return map[string]string{"go.mod": "module evil\n"}
+		}}
+
+		err := applyPacks(target, []*pack{p})
+		if err == nil || !strings.Contains(err.Error(), "go.mod already exists") {
+			t.Errorf("Expected 'already exists' error, got: %v", err)
+		}
+	})
+
+	t.Run("inserts readme sections before license", func(t *testing.T) {
+		target := newTarget()
+		p := &pack{name: "docs", readme: func(*packTarget) string {
+			return "## Extra\n\nMore docs."
+		}}
+
+		if err := applyPacks(target, []*pack{p}); err != nil {
+			t.Fatalf("applyPacks() failed: %v", err)
+		}
+		readme := target.files["README.md"]
+		extra, license := strings.Index(readme, "## Extra"), strings.Index(readme, "## License")
+		if extra < 0 || license < 0 || extra > license {
+			t.Errorf("Section not inserted before License:\n%s", readme)
+		}
+		if !strings.Contains(readme, "More docs.\n\n## License") {
+			t.Errorf("Section not separated from License:\n%s", readme)
+		}
+	})
+
+	t.Run("appends new gitignore entries", func(t *testing.T) {
+		target := newTarget()
+		p := &pack{name: "artifacts", gitignore: []string{"bin/", "dist/"}}
+
+		if err := applyPacks(target, []*pack{p}); err != nil {
+			t.Fatalf("applyPacks() failed: %v", err)
+		}
+		gitignore := target.files[".gitignore"]
+		if !strings.HasSuffix(gitignore, "\n\n# artifacts\ndist/\n") {
+			t.Errorf("Unexpected .gitignore ending:\n%s", gitignore)
+		}
+		if strings.Count(gitignore, "bin/") != 1 {
+			t.Error("Existing gitignore entry added again")
+		}
+	})
+
+	t.Run("adds go.mod requires", func(t *testing.T) {
+		target := newTarget()
+		p := &pack{name: "deps", goRequires: []module.Version{{Path: "example.com/dep", Version: "v1.2.3"}}}
+
+		if err := applyPacks(target, []*pack{p}); err != nil {
+			t.Fatalf("applyPacks() failed: %v", err)
+		}
+		goMod := target.files["go.mod"]
+		for _, want := range []string{"module github.com/user/myapp", "github.com/lmittmann/tint v1.1.2", "example.com/dep v1.2.3"} {
+			if !strings.Contains(goMod, want) {
+				t.Errorf("go.mod doesn't contain %q:\n%s", want, goMod)
+			}
+		}
+	})
+}
+
+func TestApplyPacks_Main(t *testing.T) {
+	p := &pack{name: "flags", main: func(_ *packTarget, m *mainGo) {
+		m.flags = append(m.flags, "\tverbose := flag.Bool(\"verbose\", false, \"log more\")\n\t_ = verbose")
+	}}
+
+	t.Run("re-renders main.go", func(t *testing.T) {
+		gen := NewGoGenerator()
+		_, files := gen.layout("myapp")
+		target := gen.packTarget("myapp", files)
+
+		if err := applyPacks(target, []*pack{p}); err != nil {
+			t.Fatalf("applyPacks() failed: %v", err)
+		}
+		if !strings.Contains(files[filepath.Join("cmd", "myapp", "main.go")], "flag.Parse()") {
+			t.Error("main.go wasn't re-rendered with the pack's flags")
+		}
+	})
+
+	t.Run("needs a composable main.go", func(t *testing.T) {
+		target := &packTarget{kind: "go", files: map[string]string{}}
+
+		err := applyPacks(target, []*pack{p})
+		if err == nil || !strings.Contains(err.Error(), "no main.go") {
+			t.Errorf("Expected 'no main.go' error, got: %v", err)
+		}
+	})
+}
+
+func TestApplyPacks_Server(t *testing.T) {
+	p := &pack{name: "health", server: func(_ *packTarget, s *serverGo) {
+		s.routes = append(s.routes, "\tmux.HandleFunc(\"GET /healthz\", func(http.ResponseWriter, *http.Request) {})")
+		s.middleware = append(s.middleware, "Timeout")
+	}}
+
+	t.Run("re-renders server.go", func(t *testing.T) {
+		gen := NewFullstackGenerator()
+		_, files := gen.layout("myapp")
+		target := gen.packTarget("myapp", files)
+
+		if err := applyPacks(target, []*pack{p}); err != nil {
+			t.Fatalf("applyPacks() failed: %v", err)
+		}
+		serverGo := files[filepath.Join("internal", "server", "server.go")]
+		for _, want := range []string{`mux.HandleFunc("GET /healthz"`, "[]Middleware{Timeout, Recover, Logger}"} {
+			if !strings.Contains(serverGo, want) {
+				t.Errorf("server.go doesn't contain %q:\n%s", want, serverGo)
+			}
+		}
+	})
+
+	t.Run("needs an HTTP server", func(t *testing.T) {
+		gen := NewGoGenerator()
+		_, files := gen.layout("myapp")
+
+		err := applyPacks(gen.packTarget("myapp", files), []*pack{p})
+		if err == nil || !strings.Contains(err.Error(), "no HTTP server") {
+			t.Errorf("Expected 'no HTTP server' error, got: %v", err)
+		}
+	})
+}
+
+func TestRaiseGoVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		goMod  string
+		min    string
+		wantGo string
+	}{
+		{name: "raises older", goMod: "module m\n\ngo 1.22\n", min: "1.23.0", wantGo: "go 1.23.0"},
+		{name: "keeps newer", goMod: "module m\n\ngo 1.24\n", min: "1.23.0", wantGo: "go 1.24"},
+		{name: "keeps equal", goMod: "module m\n\ngo 1.23.0\n", min: "1.23.0", wantGo: "go 1.23.0"},
+		{name: "adds missing", goMod: "module m\n", min: "1.23.0", wantGo: "go 1.23.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := raiseGoVersion(tt.goMod, tt.min)
+			if err != nil {
+				t.Fatalf("raiseGoVersion() failed: %v", err)
+			}
+			if !strings.Contains(got, tt.wantGo+"\n") {
+				t.Errorf("Expected %q in:\n%s", tt.wantGo, got)
+			}
+		})
+	}
+}
+
+func TestGoGenerator_WithPacks(t *testing.T) {
+	registry := builtinPacks
+	defer func() { builtinPacks = registry }()
+	builtinPacks = []*pack{
+		{
+			name:  "hello",
+			kinds: []string{"go"},
+			files: func(t *packTarget) map[string]string {
+				return map[string]string{"HELLO.md": "Hello " + t.modulePath}
+			},
+			gitignore: []string{"hello.out"},
+		},
+	}
+
+	t.Run("rejects unknown packs", func(t *testing.T) {
+		gen := NewGoGenerator()
+		if err := gen.WithPacks("nope"); err == nil {
+			t.Error("Expected error for an unknown pack")
+		}
+	})
+
+	t.Run("layers packs on the generated project", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldDir, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("Failed to get working directory: %v", err)
+		}
+		defer os.Chdir(oldDir)
+
+		if err := os.Chdir(tmpDir); err != nil {
+			t.Fatalf("Failed to change directory: %v", err)
+		}
+
+		gen := NewGoGenerator()
+		if err := gen.WithPacks("hello"); err != nil {
+			t.Fatalf("WithPacks() failed: %v", err)
+		}
+		if err := gen.Generate("github.com/user/myapp"); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		hello, err := os.ReadFile(filepath.Join("myapp", "HELLO.md"))
+		if err != nil {
+			t.Fatalf("Pack file not written: %v", err)
+		}
+		if string(hello) != "Hello github.com/user/myapp" {
+			t.Errorf("Unexpected pack file content: %q", hello)
+		}
+
+		gitignore, err := os.ReadFile(filepath.Join("myapp", ".gitignore"))
+		if err != nil {
+			t.Fatalf("Failed to read .gitignore: %v", err)
+		}
+		if !strings.Contains(string(gitignore), "hello.out") {
+			t.Error(".gitignore doesn't include the pack entries")
+		}
+	})
+}
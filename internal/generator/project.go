@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeProject creates dirs and files under root. Both are given relative to
+// root, so generators can build their layout in memory and combine it with
+// the layout of other generators before anything touches the disk.
+func writeProject(root string, dirs []string, files map[string]string) error {
+	// Create directories
+	for _, dir := range append([]string{"."}, dirs...) {
+		path := filepath.Join(root, dir)
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", path, err)
+		}
+	}
+
+	// Create files
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to create file %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// prefixLayout moves a layout into the subdirectory dir.
+func prefixLayout(dir string, dirs []string, files map[string]string) ([]string, map[string]string) {
+	prefixedDirs := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		prefixedDirs = append(prefixedDirs, filepath.Join(dir, d))
+	}
+
+	prefixedFiles := make(map[string]string, len(files))
+	for name, content := range files {
+		prefixedFiles[filepath.Join(dir, name)] = content
+	}
+
+	return prefixedDirs, prefixedFiles
+}
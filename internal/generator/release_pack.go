@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// releasePack stamps the binary with its version and adds a GoReleaser
+// configuration to publish it.
+var releasePack = &pack{
+	name:        "release",
+	description: "version stamping, --version flag and .goreleaser.yaml",
+	kinds:       []string{"go"},
+	files: func(t *packTarget) map[string]string {
+		cmdDir := filepath.Join("cmd", t.shortName)
+		return map[string]string{
+			filepath.Join(cmdDir, "version.go"):      versionGoTemplate(),
+			filepath.Join(cmdDir, "version_test.go"): versionTestTemplate(),
+			".goreleaser.yaml":                       goreleaserTemplate(t),
+		}
+	},
+	readme:    releaseReadmeTemplate,
+	gitignore: []string{"dist/"},
+	main: func(t *packTarget, m *mainGo) {
+		m.addImport("fmt")
+		m.flags = append(m.flags, `	showVersion := flag.Bool("version", false, "print version information and exit")`)
+		m.setup = append(m.setup, fmt.Sprintf(`	if *showVersion {
+		fmt.Println(%q, versionString())
+		return
+	}`, t.shortName))
+	},
+}
+
+func versionGoTemplate() string {
+	return `package main
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Release builds set these with -ldflags, see .goreleaser.yaml:
+//
+//	go build -ldflags "-X main.version=v1.0.0 -X main.commit=$(git rev-parse HEAD)"
+var (
+	version string
+	commit  string
+	date    string
+)
+
+// versionInfo returns the version, commit and build date. Values not set
+// with -ldflags fall back to what go build records in the binary: the
+// module version for "go install pkg@version", and VCS stamps for builds
+// from a git checkout.
+func versionInfo() (v, c, d string) {
+	v, c, d = version, commit, date
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			v = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if c == "" {
+					c = setting.Value
+				}
+			case "vcs.time":
+				if d == "" {
+					d = setting.Value
+				}
+			}
+		}
+	}
+
+	if v == "" {
+		v = "dev"
+	}
+	if c == "" {
+		c = "unknown"
+	}
+	if d == "" {
+		d = "unknown"
+	}
+	return v, c, d
+}
+
+// versionString formats versionInfo for --version
+func versionString() string {
+	v, c, d := versionInfo()
+	return fmt.Sprintf("%s (commit %s, built %s)", v, c, d)
+}
+`
+}
+
+func versionTestTemplate() string {
+	return `package main
+
+import "testing"
+
+func TestVersionInfo(t *testing.T) {
+	t.Run("uses the values set with -ldflags", func(t *testing.T) {
+		defer func(v, c, d string) { version, commit, date = v, c, d }(version, commit, date)
+		version, commit, date = "v1.2.3", "abc123", "2025-01-02T03:04:05Z"
+
+		want := "v1.2.3 (commit abc123, built 2025-01-02T03:04:05Z)"
+		if got := versionString(); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("falls back without -ldflags", func(t *testing.T) {
+		v, c, d := versionInfo()
+		if v == "" || c == "" || d == "" {
+			t.Errorf("Expected fallback values, got %q, %q, %q", v, c, d)
+		}
+	})
+}
+`
+}
+
+func goreleaserTemplate(t *packTarget) string {
+	return fmt.Sprintf(`# https://goreleaser.com/customization/
+version: 2
+
+before:
+  hooks:
+    - go mod tidy
+
+builds:
+  - id: %[1]s
+    main: ./cmd/%[1]s
+    binary: %[1]s
+    env:
+      - CGO_ENABLED=0
+    goos: [linux, darwin, windows]
+    goarch: [amd64, arm64]
+    flags: [-trimpath]
+    ldflags:
+      - -s -w -X main.version={{.Version}} -X main.commit={{.Commit}} -X main.date={{.Date}}
+
+archives:
+  - formats: [tar.gz]
+    name_template: "{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}"
+    format_overrides:
+      - goos: windows
+        formats: [zip]
+    files:
+      - README.md
+      - LICENSE
+
+checksum:
+  name_template: checksums.txt
+
+changelog:
+  use: git
+  sort: asc
+  filters:
+    exclude:
+      - "^docs:"
+      - "^test:"
+      - "^chore:"
+`, t.shortName)
+}
+
+func releaseReadmeTemplate(t *packTarget) string {
+	return fmt.Sprintf(`## Releasing
+
+`+"```bash"+`
+%[1]s --version
+`+"```"+`
+
+prints the version, commit and build date. [GoReleaser](https://goreleaser.com)
+sets them with `+"`-ldflags`"+`; other builds fall back to the module version and
+the VCS information `+"`go build`"+` records.
+
+`+"```bash"+`
+goreleaser release --snapshot --clean  # local dry run into dist/
+git tag v0.1.0 && git push --tags
+goreleaser release --clean
+`+"```"+`
+`, t.shortName)
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestReleasePack_Main(t *testing.T) {
+	target := packedProject(t, "go", "github.com/user/myapp", "release")
+	mainGo := target.files[filepath.Join("cmd", "myapp", "main.go")]
+
+	for _, want := range []string{
+		"\t\"flag\"\n",
+		`showVersion := flag.Bool("version", false, "print version information and exit")`,
+		"flag.Parse()",
+		`fmt.Println("myapp", versionString())`,
+		`slog.Info("Starting myapp")`,
+	} {
+		if !strings.Contains(mainGo, want) {
+			t.Errorf("main.go doesn't contain %q:\n%s", want, mainGo)
+		}
+	}
+}
+
+func TestReleasePack_Version(t *testing.T) {
+	target := packedProject(t, "go", "myapp", "release")
+	versionGo := target.files[filepath.Join("cmd", "myapp", "version.go")]
+
+	for _, want := range []string{"version string", "commit  string", "date    string", "debug.ReadBuildInfo()", "vcs.revision"} {
+		if !strings.Contains(versionGo, want) {
+			t.Errorf("version.go doesn't contain %q", want)
+		}
+	}
+	if _, ok := target.files[filepath.Join("cmd", "myapp", "version_test.go")]; !ok {
+		t.Error("version_test.go not added")
+	}
+}
+
+func TestReleasePack_Goreleaser(t *testing.T) {
+	target := packedProject(t, "go", "github.com/user/myapp", "release")
+
+	var config struct {
+		Version int `yaml:"version"`
+		Builds  []struct {
+			Main    string   `yaml:"main"`
+			Binary  string   `yaml:"binary"`
+			Ldflags []string `yaml:"ldflags"`
+		} `yaml:"builds"`
+		Archives  []map[string]any `yaml:"archives"`
+		Checksum  map[string]any   `yaml:"checksum"`
+		Changelog map[string]any   `yaml:"changelog"`
+	}
+	if err := yaml.Unmarshal([]byte(target.files[".goreleaser.yaml"]), &config); err != nil {
+		t.Fatalf("Invalid .goreleaser.yaml: %v", err)
+	}
+
+	if config.Version != 2 {
+		t.Errorf("Expected config version 2, got %d", config.Version)
+	}
+	if len(config.Builds) != 1 || config.Builds[0].Main != "./cmd/myapp" || config.Builds[0].Binary != "myapp" {
+		t.Fatalf("Unexpected builds: %+v", config.Builds)
+	}
+	ldflags := strings.Join(config.Builds[0].Ldflags, " ")
+	for _, want := range []string{"-X main.version={{.Version}}", "-X main.commit={{.Commit}}", "-X main.date={{.Date}}"} {
+		if !strings.Contains(ldflags, want) {
+			t.Errorf("ldflags don't contain %q: %s", want, ldflags)
+		}
+	}
+	if len(config.Archives) == 0 || config.Checksum == nil || config.Changelog == nil {
+		t.Error("Expected archives, checksum and changelog configuration")
+	}
+}
+
+func TestReleasePack_Gitignore(t *testing.T) {
+	target := packedProject(t, "go", "myapp", "release")
+
+	if !strings.Contains(target.files[".gitignore"], "\ndist/\n") {
+		t.Error(".gitignore doesn't ignore GoReleaser's dist/")
+	}
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+)
+
+// serverGo is the generated internal/server/server.go of an HTTP service,
+// assembled from parts like mainGo so feature packs can mount routes and
+// extend the middleware chain instead of replacing the file.
+type serverGo struct {
+	projectName string
+	// module is the project's module path, see mainGo.module
+	module  string
+	imports []string
+	// routes register handlers on mux, after the API routes and before the
+	// static files that catch everything else
+	routes []string
+	// middleware are Middleware expressions run in order before Recover and
+	// Logger, so they see the status of requests Recover turns into 500s and
+	// Logger sees the request context they set up
+	middleware []string
+}
+
+// addImport adds import paths that aren't imported yet
+func (s *serverGo) addImport(paths ...string) {
+	for _, path := range paths {
+		if !slices.Contains(s.imports, path) {
+			s.imports = append(s.imports, path)
+		}
+	}
+}
+
+// render returns the gofmt-formatted source of server.go
+func (s *serverGo) render() string {
+	imports := append([]string{"encoding/json", "io/fs", "net/http", "strings"}, s.imports...)
+
+	var routes string
+	if len(s.routes) > 0 {
+		routes = "\n" + strings.Join(trimBlocks(s.routes), "\n") + "\n"
+	}
+
+	chain := append(slices.Clone(s.middleware), "Recover", "Logger")
+
+	src := fmt.Sprintf(`// Package server implements the HTTP API and, in production builds, serves
+// the embedded Elm app.
+package server
+
+%[2]s// New returns the application handler. API routes live under /api/. When
+// static is non-nil it also serves the Elm app, falling back to index.html
+// for unknown paths so client-side routes work.
+func New(static fs.FS) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/hello", handleHello)
+%[3]s
+	if static != nil {
+		mux.Handle("/", spaHandler(static))
+	}
+
+	return Chain(mux, middleware...)
+}
+
+// middleware wraps every request, the first one outermost.
+var middleware = []Middleware{%[4]s}
+
+func handleHello(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Hello from %[1]s!"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// spaHandler serves files from static and index.html for everything else.
+func spaHandler(static fs.FS) http.Handler {
+	files := http.FileServerFS(static)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		if name != "" {
+			if _, err := fs.Stat(static, name); err != nil {
+				r = r.Clone(r.Context())
+				r.URL.Path = "/"
+			}
+		}
+		files.ServeHTTP(w, r)
+	})
+}
+`, s.projectName, importBlock(imports, s.module), routes, strings.Join(chain, ", "))
+
+	if formatted, err := format.Source([]byte(src)); err == nil {
+		return string(formatted)
+	}
+	return src
+}
@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// task is a development task of a generated project. The same list is
+// written out as a Taskfile.yml or a Makefile, depending on the taskRunner.
+type task struct {
+	name    string
+	desc    string
+	aliases []string
+	// dir is the directory the commands run in, relative to the project root
+	dir string
+	// env is set for every command, as "KEY=value"
+	env []string
+	// deps run before cmds; Task runs them in parallel
+	deps []string
+	cmds []string
+}
+
+// taskRunner writes the tasks of a generated project for Task
+// (https://taskfile.dev) or, when makefile is set, for make.
+type taskRunner struct {
+	makefile bool
+}
+
+// filename is the file the tasks are written to
+func (r taskRunner) filename() string {
+	if r.makefile {
+		return "Makefile"
+	}
+	return "Taskfile.yml"
+}
+
+// command is the shell command that runs the named task, for READMEs
+func (r taskRunner) command(name string) string {
+	if r.makefile {
+		return "make " + makeTarget(name)
+	}
+	return "task " + name
+}
+
+// render writes tasks in the runner's format, with defaultTask running when
+// no task is named
+func (r taskRunner) render(defaultTask string, tasks []task) string {
+	if r.makefile {
+		return makefileTemplate(defaultTask, tasks)
+	}
+	return taskfileTemplate(defaultTask, tasks)
+}
+
+func taskfileTemplate(defaultTask string, tasks []task) string {
+	var b strings.Builder
+	b.WriteString("# https://taskfile.dev\n\nversion: \"3\"\n\ntasks:\n")
+
+	for _, t := range tasks {
+		if t.name == defaultTask {
+			fmt.Fprintf(&b, "  default:\n    desc: %s\n    deps: [%s]\n    silent: true\n\n", t.desc, t.name)
+			break
+		}
+	}
+
+	for i, t := range tasks {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "  %s:\n    desc: %s\n", t.name, t.desc)
+		if len(t.aliases) > 0 {
+			fmt.Fprintf(&b, "    aliases: [%s]\n", strings.Join(t.aliases, ", "))
+		}
+		if t.dir != "" {
+			fmt.Fprintf(&b, "    dir: %s\n", t.dir)
+		}
+		if len(t.env) > 0 {
+			b.WriteString("    env:\n")
+			for _, env := range t.env {
+				key, value, _ := strings.Cut(env, "=")
+				fmt.Fprintf(&b, "      %s: %s\n", key, value)
+			}
+		}
+		if len(t.deps) > 0 {
+			fmt.Fprintf(&b, "    deps: [%s]\n", strings.Join(t.deps, ", "))
+		}
+		if len(t.cmds) > 0 {
+			b.WriteString("    cmds:\n")
+			for _, cmd := range t.cmds {
+				fmt.Fprintf(&b, "      - %s\n", taskfileQuote(cmd))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// taskfileQuote quotes cmd when YAML wouldn't read it as a plain string
+func taskfileQuote(cmd string) string {
+	if strings.ContainsAny(cmd, "'\"") || strings.Contains(cmd, ": ") || strings.Contains(cmd, " #") ||
+		strings.HasPrefix(cmd, "!") || strings.HasPrefix(cmd, "{") {
+		return "'" + strings.ReplaceAll(cmd, "'", "''") + "'"
+	}
+	return cmd
+}
+
+func makefileTemplate(defaultTask string, tasks []task) string {
+	var phony []string
+	for _, t := range tasks {
+		phony = append(phony, makeTarget(t.name))
+		phony = append(phony, t.aliases...)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ".DEFAULT_GOAL := %s\n.PHONY: %s\n", makeTarget(defaultTask), strings.Join(phony, " "))
+
+	for _, t := range tasks {
+		target := makeTarget(t.name)
+
+		var prereqs, recipe []string
+		switch {
+		case len(t.deps) == 1:
+			prereqs = append(prereqs, makeTarget(t.deps[0]))
+		case len(t.deps) > 1:
+			// Like Task, run several dependencies in parallel
+			var deps []string
+			for _, dep := range t.deps {
+				deps = append(deps, makeTarget(dep))
+			}
+			recipe = append(recipe, fmt.Sprintf("@$(MAKE) --no-print-directory -j%d %s", len(deps), strings.Join(deps, " ")))
+		}
+
+		prefix := ""
+		if t.dir != "" {
+			prefix = "cd " + t.dir + " && "
+		}
+		if len(t.env) > 0 {
+			prefix += strings.Join(t.env, " ") + " "
+		}
+		for _, cmd := range t.cmds {
+			recipe = append(recipe, prefix+strings.ReplaceAll(cmd, "$", "$$"))
+		}
+
+		fmt.Fprintf(&b, "\n# %s\n%s:", t.desc, target)
+		if len(prereqs) > 0 {
+			b.WriteString(" " + strings.Join(prereqs, " "))
+		}
+		b.WriteString("\n")
+		for _, line := range recipe {
+			b.WriteString("\t" + line + "\n")
+		}
+		for _, alias := range t.aliases {
+			fmt.Fprintf(&b, "%s: %s\n", alias, target)
+		}
+	}
+
+	return b.String()
+}
+
+// makeTarget turns a task name like "dev:api" into a make target; make
+// reads colons as rule separators
+func makeTarget(name string) string {
+	return strings.ReplaceAll(name, ":", "-")
+}
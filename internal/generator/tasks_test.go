@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var testTasks = []task{
+	{name: "dev", desc: "Run everything", aliases: []string{"d"}, deps: []string{"dev:api", "dev:web"}},
+	{name: "dev:api", desc: "Run the API", cmds: []string{"go run ./cmd/app"}},
+	{name: "dev:web", desc: "Run the web app", dir: "web", cmds: []string{"npm run dev"}},
+	{name: "build", desc: "Build", env: []string{"GOOS=js"}, deps: []string{"dev:web"}, cmds: []string{"go build $(go list -m)"}},
+}
+
+// taskfileYAML is the part of Taskfile.yml the tests check
+type taskfileYAML struct {
+	Version string `yaml:"version"`
+	Tasks   map[string]struct {
+		Desc    string            `yaml:"desc"`
+		Aliases []string          `yaml:"aliases"`
+		Dir     string            `yaml:"dir"`
+		Env     map[string]string `yaml:"env"`
+		Deps    []string          `yaml:"deps"`
+		Cmds    []string          `yaml:"cmds"`
+	} `yaml:"tasks"`
+}
+
+func parseTaskfile(t *testing.T, content string) taskfileYAML {
+	t.Helper()
+
+	var taskfile taskfileYAML
+	if err := yaml.Unmarshal([]byte(content), &taskfile); err != nil {
+		t.Fatalf("Invalid Taskfile.yml: %v\n%s", err, content)
+	}
+	return taskfile
+}
+
+func TestTaskRunner_Taskfile(t *testing.T) {
+	r := taskRunner{}
+	if r.filename() != "Taskfile.yml" || r.command("dev:api") != "task dev:api" {
+		t.Errorf("Unexpected filename %q or command %q", r.filename(), r.command("dev:api"))
+	}
+
+	taskfile := parseTaskfile(t, r.render("dev", testTasks))
+
+	if taskfile.Version != "3" {
+		t.Errorf("Expected version 3, got %q", taskfile.Version)
+	}
+	if deps := taskfile.Tasks["default"].Deps; len(deps) != 1 || deps[0] != "dev" {
+		t.Errorf("Default task should run dev, got %v", deps)
+	}
+	if got := taskfile.Tasks["dev"].Deps; strings.Join(got, ",") != "dev:api,dev:web" {
+		t.Errorf("Unexpected dev deps: %v", got)
+	}
+	if taskfile.Tasks["dev:web"].Dir != "web" {
+		t.Error("dev:web doesn't run in web/")
+	}
+	build := taskfile.Tasks["build"]
+	if build.Env["GOOS"] != "js" {
+		t.Errorf("build env not set: %v", build.Env)
+	}
+	if len(build.Cmds) != 1 || build.Cmds[0] != "go build $(go list -m)" {
+		t.Errorf("Unexpected build cmds: %q", build.Cmds)
+	}
+}
+
+func TestTaskRunner_Makefile(t *testing.T) {
+	r := taskRunner{makefile: true}
+	if r.filename() != "Makefile" || r.command("dev:api") != "make dev-api" {
+		t.Errorf("Unexpected filename %q or command %q", r.filename(), r.command("dev:api"))
+	}
+
+	content := r.render("dev", testTasks)
+
+	for _, want := range []string{
+		".DEFAULT_GOAL := dev\n",
+		".PHONY: dev d dev-api dev-web build\n",
+		"dev:\n\t@$(MAKE) --no-print-directory -j2 dev-api dev-web\nd: dev\n",
+		"dev-web:\n\tcd web && npm run dev\n",
+		"build: dev-web\n\tGOOS=js go build $$(go list -m)\n",
+		"# Run the API\ndev-api:\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Makefile doesn't contain %q:\n%s", want, content)
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, " ") {
+			t.Errorf("Recipe line indented with spaces: %q", line)
+		}
+	}
+}
+
+func TestGenerators_Tasks(t *testing.T) {
+	wasm := NewGoWasmGenerator()
+	wasm.goroot = fakeGOROOT(t, filepath.Join("lib", "wasm", "wasm_exec.js"))
+
+	layouts := map[string]func() map[string]string{
+		"go": func() map[string]string {
+			_, files := NewGoGenerator().layout("github.com/user/myapp")
+			return files
+		},
+		"vite-elm": func() map[string]string {
+			_, files := NewViteElmGenerator().layout("myapp")
+			return files
+		},
+		"go-tui": func() map[string]string {
+			_, files := NewGoTUIGenerator().layout("github.com/user/myapp")
+			return files
+		},
+		"fullstack": func() map[string]string {
+			_, files := NewFullstackGenerator().layout("github.com/user/myapp")
+			return files
+		},
+		"go-wasm": func() map[string]string {
+			_, files, err := wasm.layout("github.com/user/myapp")
+			if err != nil {
+				t.Fatalf("layout() failed: %v", err)
+			}
+			return files
+		},
+		"go-workspace": func() map[string]string {
+			_, files := NewGoWorkspaceGenerator().layout("github.com/user/myapp")
+			return files
+		},
+		"go-db": func() map[string]string {
+			_, files := NewGoDBGenerator().layout("github.com/user/myapp")
+			return files
+		},
+	}
+
+	for name, layout := range layouts {
+		t.Run(name, func(t *testing.T) {
+			files := layout()
+
+			content, ok := files["Taskfile.yml"]
+			if !ok {
+				t.Fatal("No Taskfile.yml at the project root")
+			}
+			taskfile := parseTaskfile(t, content)
+			for _, want := range []string{"dev", "test", "build", "lint", "clean"} {
+				if _, ok := taskfile.Tasks[want]; !ok {
+					t.Errorf("Taskfile.yml has no %s task", want)
+				}
+			}
+
+			for path := range files {
+				if path != "Taskfile.yml" && filepath.Base(path) == "Taskfile.yml" {
+					t.Errorf("Unexpected nested %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerators_GoBinariesInBin(t *testing.T) {
+	_, files := NewGoGenerator().layout("github.com/user/myapp")
+	taskfile := parseTaskfile(t, files["Taskfile.yml"])
+
+	build := taskfile.Tasks["build"]
+	if len(build.Cmds) != 1 || build.Cmds[0] != "go build -o bin/myapp ./cmd/myapp" {
+		t.Errorf("Unexpected build cmds: %q", build.Cmds)
+	}
+	if !strings.Contains(NewGoGenerator().gitignoreTemplate(), "bin/\n") {
+		t.Error(".gitignore doesn't ignore bin/")
+	}
+}
+
+func TestGenerators_UseMakefile(t *testing.T) {
+	t.Run("go", func(t *testing.T) {
+		gen := NewGoGenerator()
+		gen.UseMakefile()
+		_, files := gen.layout("myapp")
+
+		if _, ok := files["Taskfile.yml"]; ok {
+			t.Error("Taskfile.yml written alongside the Makefile")
+		}
+		if !strings.Contains(files["Makefile"], "build:\n\tgo build -o bin/myapp ./cmd/myapp\n") {
+			t.Errorf("Unexpected Makefile:\n%s", files["Makefile"])
+		}
+	})
+
+	t.Run("vite-elm", func(t *testing.T) {
+		gen := NewViteElmGenerator()
+		gen.UseMakefile()
+		_, files := gen.layout("myapp")
+
+		if !strings.Contains(files["Makefile"], "build:\n\tnpx vite build\n") {
+			t.Errorf("Unexpected Makefile:\n%s", files["Makefile"])
+		}
+	})
+
+	t.Run("fullstack", func(t *testing.T) {
+		gen := NewFullstackGenerator()
+		gen.UseMakefile()
+		_, files := gen.layout("shop")
+
+		if _, ok := files[filepath.Join("web", "Makefile")]; ok {
+			t.Error("web/ shouldn't have its own Makefile")
+		}
+		if !strings.Contains(files["README.md"], "make dev\n") {
+			t.Error("README doesn't use make")
+		}
+		if !strings.Contains(files["Makefile"], "build: build-web\n") {
+			t.Errorf("Unexpected Makefile:\n%s", files["Makefile"])
+		}
+	})
+
+	t.Run("go-workspace", func(t *testing.T) {
+		gen := NewGoWorkspaceGenerator()
+		gen.UseMakefile()
+		_, files := gen.layout("mono")
+
+		if _, ok := files["Makefile"]; !ok {
+			t.Error("No Makefile at the workspace root")
+		}
+		if _, ok := files[filepath.Join("api", "Makefile")]; ok {
+			t.Error("Workspace modules shouldn't have their own Makefile")
+		}
+	})
+}
@@ -5,34 +5,20 @@ import (
 	"time"
 )
 
-func (g *GoGenerator) mainGoTemplate(projectName string) string {
-	return fmt.Sprintf(`package main
-
-import (
-	"fmt"
-	"log/slog"
-	"os"
-
-	"github.com/lmittmann/tint"
-)
-
-func init() {
-	// Initialize structured logging with colored output
-	slog.SetDefault(slog.New(
-		tint.NewHandler(os.Stderr, &tint.Options{
-			Level:      slog.LevelInfo,
-			TimeFormat: "15:04:05.0000",
-			NoColor:    false,
-			AddSource:  false,
-		}),
-	))
+func (g *GoGenerator) mainGoTemplate(projectName, modulePath string) string {
+	return g.mainGo(projectName, modulePath).render()
 }
 
-func main() {
-	slog.Info("Starting %s")
-	fmt.Println("Hello from %s!")
-}
-`, projectName, projectName)
+// mainGo is the main.go of a Go project, before feature packs add to it
+func (g *GoGenerator) mainGo(projectName, modulePath string) *mainGo {
+	m := g.logging.mainGo(projectName)
+	m.module = modulePath
+	m.addImport("fmt")
+	m.body = []string{
+		fmt.Sprintf("\tslog.Info(\"Starting %s\")", projectName),
+		fmt.Sprintf("\tfmt.Println(\"Hello from %s!\")", projectName),
+	}
+	return m
 }
 
 func (g *GoGenerator) mainTestTemplate(projectName string) string {
@@ -48,12 +34,16 @@ func TestMain(t *testing.T) {
 }
 
 func (g *GoGenerator) goModTemplate(modulePath string) string {
-	return fmt.Sprintf(`module %s
+	return g.goModFor(modulePath, "1.21")
+}
 
-go 1.21
+// goModFor is the go.mod of a Go project with the given go directive, for
+// templates whose code or dependencies need a newer Go
+func (g *GoGenerator) goModFor(modulePath, goVersion string) string {
+	return fmt.Sprintf(`module %s
 
-require github.com/lmittmann/tint v1.1.2
-`, modulePath)
+go %s
+%s`, modulePath, goVersion, g.logging.goRequire(g.versions))
 }
 
 func (g *GoGenerator) readmeTemplate(projectName, modulePath string) string {
@@ -63,10 +53,7 @@ Created with projectstarter
 
 ## Installation
 
-`+"```bash"+`
-go mod tidy
-`+"```"+`
-
+`+goSumReadme+`
 ## Usage
 
 `+"```bash"+`
@@ -85,6 +72,19 @@ MIT
 `, projectName, projectName)
 }
 
+func (g *GoGenerator) tasks(projectName string) []task {
+	return []task{
+		{name: "dev", desc: "Run the app", aliases: []string{"d"}, cmds: []string{"go run ./cmd/" + projectName}},
+		{name: "test", desc: "Run all tests", aliases: []string{"t"}, cmds: []string{"go test ./..."}},
+		{name: "build", desc: "Build the binary into bin/", aliases: []string{"b"}, cmds: []string{"go build -o bin/" + projectName + " ./cmd/" + projectName}},
+		{name: "lint", desc: "Vet the code and list unformatted files", aliases: []string{"l"}, cmds: goLintCmds},
+		{name: "clean", desc: "Remove build output", cmds: []string{"rm -rf bin"}},
+	}
+}
+
+// goLintCmds vet the module and fail on files gofmt would change
+var goLintCmds = []string{"go vet ./...", "gofmt -l . | (! grep .)"}
+
 func (g *GoGenerator) licenseTemplate() string {
 	year := time.Now().Year()
 	return fmt.Sprintf(`MIT License
@@ -129,9 +129,11 @@ bin/
 # Go workspace file
 go.work
 
-# IDE
+# IDE (shared VS Code settings are kept)
 .idea/
-.vscode/
+.vscode/*
+!.vscode/settings.json
+!.vscode/extensions.json
 *.swp
 *.swo
 *~
@@ -0,0 +1,222 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// goTemplate is a generator that produces Go code, named like its
+// "proj start" subcommand, which is also the kind feature packs declare.
+type goTemplate struct {
+	name string
+	// variant tells apart generator options of the same template
+	variant string
+	layout  func(modulePath string) ([]string, map[string]string, error)
+	// target, when set, returns the pack target for the layout's files
+	target func(modulePath string, files map[string]string) *packTarget
+	// check, when set, rejects pack combinations the generator refuses
+	check func(packs []*pack) error
+	// wasm templates are also vetted for GOOS=js GOARCH=wasm
+	wasm bool
+}
+
+func goTemplates(t *testing.T) []goTemplate {
+	wasm := NewGoWasmGenerator()
+	wasm.goroot = fakeGOROOT(t, filepath.Join("lib", "wasm", "wasm_exec.js"))
+
+	noErr := func(layout func(string) ([]string, map[string]string)) func(string) ([]string, map[string]string, error) {
+		return func(modulePath string) ([]string, map[string]string, error) {
+			dirs, files := layout(modulePath)
+			return dirs, files, nil
+		}
+	}
+
+	withLogging := func(format, levelFrom string) *GoGenerator {
+		gen := NewGoGenerator()
+		if err := gen.WithLogging(format, levelFrom); err != nil {
+			t.Fatalf("WithLogging() failed: %v", err)
+		}
+		return gen
+	}
+	textEnv, jsonFlag := withLogging("text", "env"), withLogging("json", "flag")
+
+	textEnvDB, jsonFlagWorkspace := NewGoDBGenerator(), NewGoWorkspaceGenerator()
+	if err := textEnvDB.WithLogging("text", "env"); err != nil {
+		t.Fatalf("WithLogging() failed: %v", err)
+	}
+	if err := jsonFlagWorkspace.WithLogging("json", "flag"); err != nil {
+		t.Fatalf("WithLogging() failed: %v", err)
+	}
+
+	return []goTemplate{
+		{name: "go", layout: noErr(NewGoGenerator().layout), target: NewGoGenerator().packTarget},
+		{name: "go", variant: "text-env", layout: noErr(textEnv.layout), target: textEnv.packTarget, check: textEnv.logging.checkPacks},
+		{name: "go", variant: "json-flag", layout: noErr(jsonFlag.layout), target: jsonFlag.packTarget, check: jsonFlag.logging.checkPacks},
+		{name: "go-tui", layout: noErr(NewGoTUIGenerator().layout)},
+		{name: "fullstack", layout: noErr(NewFullstackGenerator().layout), target: NewFullstackGenerator().packTarget},
+		{name: "go-wasm", layout: wasm.layout, wasm: true},
+		{name: "go-workspace", layout: noErr(NewGoWorkspaceGenerator().layout)},
+		{name: "go-workspace", variant: "json-flag", layout: noErr(jsonFlagWorkspace.layout)},
+		{name: "go-db", layout: noErr(NewGoDBGenerator().layout)},
+		{name: "go-db", variant: "text-env", layout: noErr(textEnvDB.layout)},
+	}
+}
+
+// packCombos returns every combination of the packs available for kind that
+// resolves, including none
+func packCombos(kind string) [][]*pack {
+	infos := Packs(kind)
+
+	var combos [][]*pack
+	for mask := 0; mask < 1<<len(infos); mask++ {
+		var names []string
+		for i, info := range infos {
+			if mask&(1<<i) != 0 {
+				names = append(names, info.Name)
+			}
+		}
+
+		packs, err := resolvePacks(builtinPacks, kind, names)
+		if err != nil {
+			continue // conflicting combination
+		}
+		combos = append(combos, packs)
+	}
+	return combos
+}
+
+// goFingerprint identifies the Go code of a project: combinations that only
+// differ in non-Go files vet the same
+func goFingerprint(files map[string]string) string {
+	var names []string
+	for name := range files {
+		if strings.HasSuffix(name, ".go") || filepath.Base(name) == "go.mod" || filepath.Base(name) == "go.work" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name + "\x00" + files[name] + "\x00"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// TestGoTemplates_VetAndGofmt checks that every Go template, with every
+// combination of its feature packs, is gofmt-clean and passes go vet.
+// Vetting runs offline: projects whose dependencies aren't in the local
+// module cache are skipped, as is all of vetting in -short mode.
+func TestGoTemplates_VetAndGofmt(t *testing.T) {
+	const modulePath = "github.com/user/myapp"
+
+	goBin, lookErr := exec.LookPath("go")
+
+	for _, tmpl := range goTemplates(t) {
+		vetted := map[string]string{}
+
+		for _, packs := range packCombos(tmpl.name) {
+			if tmpl.check != nil && tmpl.check(packs) != nil {
+				continue
+			}
+
+			dirs, files, err := tmpl.layout(modulePath)
+			if err != nil {
+				t.Fatalf("%s: layout() failed: %v", tmpl.name, err)
+			}
+
+			target := &packTarget{kind: tmpl.name, modulePath: modulePath, shortName: filepath.Base(modulePath), files: files}
+			if tmpl.target != nil {
+				target = tmpl.target(modulePath, files)
+			}
+			if err := applyPacks(target, packs); err != nil {
+				t.Fatalf("%s: applyPacks() failed: %v", tmpl.name, err)
+			}
+
+			name := tmpl.name
+			if tmpl.variant != "" {
+				name += "(" + tmpl.variant + ")"
+			}
+			if len(packs) > 0 {
+				name += "+" + packNames(packs)
+			}
+
+			t.Run(name, func(t *testing.T) {
+				for path, content := range files {
+					if !strings.HasSuffix(path, ".go") {
+						continue
+					}
+					formatted, err := format.Source([]byte(content))
+					if err != nil {
+						t.Errorf("%s doesn't parse: %v", path, err)
+					} else if string(formatted) != content {
+						t.Errorf("%s isn't gofmt-clean", path)
+					}
+				}
+
+				fingerprint := goFingerprint(files)
+				if same, ok := vetted[fingerprint]; ok {
+					t.Skipf("same Go code as %s", same)
+				}
+				vetted[fingerprint] = name
+
+				if testing.Short() {
+					t.Skip("vetting generated projects is slow")
+				}
+				if lookErr != nil {
+					t.Skip("go not found in PATH")
+				}
+
+				root := t.TempDir()
+				if err := writeProject(root, dirs, files); err != nil {
+					t.Fatalf("writeProject() failed: %v", err)
+				}
+
+				for path := range files {
+					if filepath.Base(path) == "go.mod" {
+						vetModule(t, goBin, filepath.Join(root, filepath.Dir(path)), tmpl.wasm)
+					}
+				}
+			})
+		}
+	}
+}
+
+// vetModule resolves the module's dependencies from the local module cache
+// only and vets it
+func vetModule(t *testing.T, goBin, dir string, wasm bool) {
+	t.Helper()
+
+	env := append(os.Environ(),
+		"GOPROXY=off",
+		"GOFLAGS=-mod=mod",
+		"GOWORK=off",
+		"GOTOOLCHAIN=local",
+	)
+	run := func(extraEnv []string, args ...string) ([]byte, error) {
+		cmd := exec.Command(goBin, args...)
+		cmd.Dir = dir
+		cmd.Env = append(env, extraEnv...)
+		return cmd.CombinedOutput()
+	}
+
+	if out, err := run(nil, "mod", "tidy"); err != nil {
+		t.Skipf("dependencies of %s not in the module cache:\n%s", dir, out)
+	}
+
+	if out, err := run(nil, "vet", "./..."); err != nil {
+		t.Errorf("go vet failed in %s:\n%s", dir, out)
+	}
+	if wasm {
+		if out, err := run([]string{"GOOS=js", "GOARCH=wasm"}, "vet", "./..."); err != nil {
+			t.Errorf("go vet for js/wasm failed in %s:\n%s", dir, out)
+		}
+	}
+}
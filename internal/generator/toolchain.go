@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"fmt"
+	"go/version"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goToolchain sets the go and toolchain directives of generated go.mod and
+// go.work files. Templates declare the oldest Go version their code and
+// dependencies need; the directives are raised to the requested or local
+// version, never lowered below that.
+type goToolchain struct {
+	// goVersion is the requested language version, like "1.24" or
+	// "1.24.2"; empty uses the local toolchain's
+	goVersion string
+	// toolchain is the toolchain directive, like "go1.25.3", "local" for
+	// the local toolchain, or empty for none
+	toolchain string
+	// local returns the version of the local toolchain, like "go1.25.3"
+	local func() (string, error)
+}
+
+// localGoVersion asks the go command on PATH for its version
+func localGoVersion() (string, error) {
+	out, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run go env GOVERSION: %w", err)
+	}
+	v := strings.TrimSpace(string(out))
+	// Development builds report versions like "devel go1.26-abcdef"
+	if !version.IsValid(v) {
+		return "", fmt.Errorf("unsupported Go version %q", v)
+	}
+	return v, nil
+}
+
+// newGoToolchain validates the requested versions: goVersion like "1.24"
+// or "1.24.2", toolchain like "go1.24.2" or "local". A "go" prefix on
+// goVersion is accepted and dropped.
+func newGoToolchain(goVersion, toolchain string) (goToolchain, error) {
+	goVersion = strings.TrimPrefix(goVersion, "go")
+	if goVersion != "" && !version.IsValid("go"+goVersion) {
+		return goToolchain{}, fmt.Errorf("invalid Go version %q, expected one like 1.24 or 1.24.2", goVersion)
+	}
+	if toolchain != "" && toolchain != "local" && !version.IsValid(toolchain) {
+		return goToolchain{}, fmt.Errorf("invalid toolchain %q, expected one like go1.24.2 or \"local\"", toolchain)
+	}
+	return goToolchain{goVersion: goVersion, toolchain: toolchain, local: localGoVersion}, nil
+}
+
+// directives returns the go and toolchain versions to write, without the
+// "go" prefix for the former. Without a go command on PATH, what can't be
+// determined is left empty, keeping the templates' versions.
+func (tc goToolchain) directives() (goVersion, toolchain string) {
+	local := ""
+	needLocal := tc.goVersion == "" || tc.toolchain == "local"
+	if needLocal && tc.local != nil {
+		if v, err := tc.local(); err == nil {
+			local = v
+		}
+	}
+
+	goVersion = tc.goVersion
+	if goVersion == "" {
+		goVersion = strings.TrimPrefix(local, "go")
+	}
+
+	toolchain = tc.toolchain
+	if toolchain == "local" {
+		toolchain = local
+	}
+	return goVersion, toolchain
+}
+
+// apply sets the directives of every go.mod and go.work in files
+func (tc goToolchain) apply(files map[string]string) error {
+	goVersion, toolchain := tc.directives()
+	if goVersion == "" && toolchain == "" {
+		return nil
+	}
+
+	for name, content := range files {
+		var (
+			out []byte
+			err error
+		)
+		switch filepath.Base(name) {
+		case "go.mod":
+			out, err = setModDirectives(name, content, goVersion, toolchain)
+		case "go.work":
+			out, err = setWorkDirectives(name, content, goVersion, toolchain)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		files[name] = string(out)
+	}
+	return nil
+}
+
+func setModDirectives(name, content, goVersion, toolchain string) ([]byte, error) {
+	f, err := modfile.Parse(name, []byte(content), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	current := ""
+	if f.Go != nil {
+		current = f.Go.Version
+	}
+	if goVersion = newerGoVersion(current, goVersion); goVersion != current {
+		if err := f.AddGoStmt(goVersion); err != nil {
+			return nil, fmt.Errorf("failed to set go %s in %s: %w", goVersion, name, err)
+		}
+	}
+	// The go command ignores toolchains not newer than the go directive
+	if toolchain != "" && version.Compare(toolchain, "go"+goVersion) > 0 {
+		if err := f.AddToolchainStmt(toolchain); err != nil {
+			return nil, fmt.Errorf("failed to set toolchain %s in %s: %w", toolchain, name, err)
+		}
+	}
+	return f.Format()
+}
+
+func setWorkDirectives(name, content, goVersion, toolchain string) ([]byte, error) {
+	f, err := modfile.ParseWork(name, []byte(content), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	current := ""
+	if f.Go != nil {
+		current = f.Go.Version
+	}
+	if goVersion = newerGoVersion(current, goVersion); goVersion != current {
+		if err := f.AddGoStmt(goVersion); err != nil {
+			return nil, fmt.Errorf("failed to set go %s in %s: %w", goVersion, name, err)
+		}
+	}
+	if toolchain != "" && version.Compare(toolchain, "go"+goVersion) > 0 {
+		if err := f.AddToolchainStmt(toolchain); err != nil {
+			return nil, fmt.Errorf("failed to set toolchain %s in %s: %w", toolchain, name, err)
+		}
+	}
+	return modfile.Format(f.Syntax), nil
+}
+
+// newerGoVersion returns the newer of two go directive versions, either of
+// which may be empty
+func newerGoVersion(a, b string) string {
+	if a == "" || (b != "" && version.Compare("go"+b, "go"+a) > 0) {
+		return b
+	}
+	return a
+}
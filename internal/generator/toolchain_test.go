@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewGoToolchain(t *testing.T) {
+	tests := []struct {
+		goVersion, toolchain string
+		wantGo               string
+		wantErr              string
+	}{
+		{goVersion: "", toolchain: ""},
+		{goVersion: "1.24", toolchain: "local", wantGo: "1.24"},
+		{goVersion: "go1.24.2", toolchain: "go1.25.0", wantGo: "1.24.2"},
+		{goVersion: "1.x", wantErr: "invalid Go version"},
+		{goVersion: "1.24", toolchain: "1.25.0", wantErr: "invalid toolchain"},
+	}
+
+	for _, tt := range tests {
+		tc, err := newGoToolchain(tt.goVersion, tt.toolchain)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("newGoToolchain(%q, %q): expected error containing %q, got %v", tt.goVersion, tt.toolchain, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("newGoToolchain(%q, %q) failed: %v", tt.goVersion, tt.toolchain, err)
+		} else if tc.goVersion != tt.wantGo {
+			t.Errorf("newGoToolchain(%q, %q): expected go version %q, got %q", tt.goVersion, tt.toolchain, tt.wantGo, tc.goVersion)
+		}
+	}
+}
+
+func TestGoToolchain_Apply(t *testing.T) {
+	local := func() (string, error) { return "go1.25.3", nil }
+	noGo := func() (string, error) { return "", errors.New("go not found") }
+
+	tests := []struct {
+		name string
+		tc   goToolchain
+		// templateGo is the go directive the template declares
+		templateGo string
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:       "uses the local version",
+			tc:         goToolchain{local: local},
+			templateGo: "1.21",
+			want:       []string{"go 1.25.3\n"},
+			notWant:    []string{"toolchain"},
+		},
+		{
+			name:       "requested version wins over the local one",
+			tc:         goToolchain{goVersion: "1.24", local: local},
+			templateGo: "1.21",
+			want:       []string{"go 1.24\n"},
+			notWant:    []string{"toolchain"},
+		},
+		{
+			name:       "pins the local toolchain",
+			tc:         goToolchain{goVersion: "1.23", toolchain: "local", local: local},
+			templateGo: "1.21",
+			want:       []string{"go 1.23\n", "toolchain go1.25.3\n"},
+		},
+		{
+			name:       "keeps a newer template version",
+			tc:         goToolchain{goVersion: "1.21", local: local},
+			templateGo: "1.23",
+			want:       []string{"go 1.23\n"},
+		},
+		{
+			name:       "omits a toolchain that isn't newer",
+			tc:         goToolchain{goVersion: "1.25.3", toolchain: "local", local: local},
+			templateGo: "1.21",
+			want:       []string{"go 1.25.3\n"},
+			notWant:    []string{"toolchain"},
+		},
+		{
+			name:       "falls back to the template without go on PATH",
+			tc:         goToolchain{local: noGo},
+			templateGo: "1.21",
+			want:       []string{"go 1.21\n"},
+			notWant:    []string{"toolchain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{
+				"go.mod":     "module example.com/app\n\ngo " + tt.templateGo + "\n\nrequire github.com/lmittmann/tint v1.1.2\n",
+				"go.work":    "go " + tt.templateGo + "\n\nuse ./api\n",
+				"README.md":  "go 1.0 stays\n",
+				"api/go.mod": "module example.com/app/api\n\ngo " + tt.templateGo + "\n",
+			}
+			if err := tt.tc.apply(files); err != nil {
+				t.Fatalf("apply() failed: %v", err)
+			}
+
+			for _, name := range []string{"go.mod", "go.work", "api/go.mod"} {
+				for _, want := range tt.want {
+					if !strings.Contains(files[name], want) {
+						t.Errorf("%s doesn't contain %q:\n%s", name, want, files[name])
+					}
+				}
+				for _, notWant := range tt.notWant {
+					if strings.Contains(files[name], notWant) {
+						t.Errorf("%s contains %q:\n%s", name, notWant, files[name])
+					}
+				}
+			}
+			if !strings.Contains(files["go.mod"], "require github.com/lmittmann/tint v1.1.2") {
+				t.Errorf("go.mod lost its requirements:\n%s", files["go.mod"])
+			}
+			if files["README.md"] != "go 1.0 stays\n" {
+				t.Errorf("README.md was changed: %q", files["README.md"])
+			}
+		})
+	}
+}
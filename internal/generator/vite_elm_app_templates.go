@@ -0,0 +1,427 @@
+package generator
+
+import "fmt"
+
+// Templates of "vite-elm --app": a Browser.application with a page per
+// route instead of the single counter.
+
+func (g *ViteElmGenerator) appMainElmTemplate(projectName string) string {
+	var imports, field, init, msg, update, root, toggle, toggleView string
+	if g.darkMode {
+		imports = `
+import Html exposing (Html, a, button, div, main_, nav, text)
+import Html.Attributes exposing (class, classList)
+import Html.Events exposing (onClick)`
+		field = `
+    , dark : Bool`
+		init = ", dark = False"
+		msg = `
+    | ToggleDarkMode`
+		update = `
+
+        ( ToggleDarkMode, _ ) ->
+            ( { model | dark = not model.dark }, Cmd.none )`
+		root = `classList [ ( "dark", model.dark ) ], `
+		toggle = `
+            , darkModeToggle model.dark`
+		toggleView = darkModeToggleElm("ml-auto text-sm text-muted hover:text-ink")
+	} else {
+		imports = `
+import Html exposing (Html, a, div, main_, nav, text)
+import Html.Attributes exposing (class, classList)`
+	}
+
+	return fmt.Sprintf(`module Main exposing (main)
+
+import Browser
+import Browser.Navigation as Nav%[2]s
+import Page.Counter as Counter
+import Page.Home as Home
+import Page.NotFound as NotFound
+import Route exposing (Route)
+import Url exposing (Url)
+
+
+-- MAIN
+
+
+main : Program () Model Msg
+main =
+    Browser.application
+        { init = init
+        , view = view
+        , update = update
+        , subscriptions = \_ -> Sub.none
+        , onUrlRequest = LinkClicked
+        , onUrlChange = UrlChanged
+        }
+
+
+-- MODEL
+
+
+type alias Model =
+    { key : Nav.Key
+    , page : Page%[3]s
+    }
+
+
+{-| The page being shown, with its state
+-}
+type Page
+    = HomePage Home.Model
+    | CounterPage Counter.Model
+    | NotFoundPage
+
+
+init : () -> Url -> Nav.Key -> ( Model, Cmd Msg )
+init _ url key =
+    ( { key = key, page = pageFor url%[4]s }, Cmd.none )
+
+
+pageFor : Url -> Page
+pageFor url =
+    case Route.fromUrl url of
+        Just Route.Home ->
+            HomePage Home.init
+
+        Just Route.Counter ->
+            CounterPage Counter.init
+
+        Nothing ->
+            NotFoundPage
+
+
+-- UPDATE
+
+
+type Msg
+    = LinkClicked Browser.UrlRequest
+    | UrlChanged Url
+    | HomeMsg Home.Msg
+    | CounterMsg Counter.Msg%[5]s
+
+
+update : Msg -> Model -> ( Model, Cmd Msg )
+update msg model =
+    case ( msg, model.page ) of
+        ( LinkClicked (Browser.Internal url), _ ) ->
+            ( model, Nav.pushUrl model.key (Url.toString url) )
+
+        ( LinkClicked (Browser.External href), _ ) ->
+            ( model, Nav.load href )
+
+        ( UrlChanged url, _ ) ->
+            ( { model | page = pageFor url }, Cmd.none )
+
+        ( HomeMsg homeMsg, HomePage home ) ->
+            ( { model | page = HomePage (Home.update homeMsg home) }, Cmd.none )
+
+        ( CounterMsg counterMsg, CounterPage counter ) ->
+            ( { model | page = CounterPage (Counter.update counterMsg counter) }, Cmd.none )%[6]s
+
+        ( _, _ ) ->
+            -- A message from a page that isn't shown anymore
+            ( model, Cmd.none )
+
+
+-- VIEW
+
+
+view : Model -> Browser.Document Msg
+view model =
+    { title = title model.page
+    , body =
+        [ div [ %[7]sclass "min-h-screen bg-canvas text-ink" ]
+            [ viewNav model
+            , main_ [ class "flex justify-center p-8" ]
+                [ viewPage model.page ]
+            ]
+        ]
+    }
+
+
+title : Page -> String
+title page =
+    case page of
+        HomePage _ ->
+            "%[1]s"
+
+        CounterPage _ ->
+            "Counter · %[1]s"
+
+        NotFoundPage ->
+            "Page not found · %[1]s"
+
+
+viewNav : Model -> Html Msg
+viewNav model =
+    nav [ class "bg-surface shadow" ]
+        [ div [ class "mx-auto flex max-w-4xl gap-6 px-8 py-4" ]
+            [ navLink model.page Route.Home "Home"
+            , navLink model.page Route.Counter "Counter"%[8]s
+            ]
+        ]
+
+
+navLink : Page -> Route -> String -> Html msg
+navLink page route label =
+    a
+        [ Route.href route
+        , classList
+            [ ( "font-semibold text-brand", isActive page route )
+            , ( "text-muted hover:text-ink", not (isActive page route) )
+            ]
+        ]
+        [ text label ]
+
+
+isActive : Page -> Route -> Bool
+isActive page route =
+    case ( page, route ) of
+        ( HomePage _, Route.Home ) ->
+            True
+
+        ( CounterPage _, Route.Counter ) ->
+            True
+
+        _ ->
+            False
+
+
+viewPage : Page -> Html Msg
+viewPage page =
+    case page of
+        HomePage home ->
+            Html.map HomeMsg (Home.view home)
+
+        CounterPage counter ->
+            Html.map CounterMsg (Counter.view counter)
+
+        NotFoundPage ->
+            NotFound.view
+%[9]s`, projectName, imports, field, init, msg, update, root, toggle, toggleView)
+}
+
+func (g *ViteElmGenerator) routeElmTemplate() string {
+	return `module Route exposing (Route(..), fromUrl, href)
+
+import Html exposing (Attribute)
+import Html.Attributes
+import Url exposing (Url)
+import Url.Parser as Parser exposing (Parser, oneOf, s, top)
+
+
+{-| The pages of the app. URLs that match none of them show the 404 page.
+-}
+type Route
+    = Home
+    | Counter
+
+
+parser : Parser (Route -> a) a
+parser =
+    oneOf
+        [ Parser.map Home top
+        , Parser.map Counter (s "counter")
+        ]
+
+
+fromUrl : Url -> Maybe Route
+fromUrl url =
+    Parser.parse parser url
+
+
+{-| The href attribute of a link to a route
+-}
+href : Route -> Attribute msg
+href route =
+    Html.Attributes.href (toPath route)
+
+
+toPath : Route -> String
+toPath route =
+    case route of
+        Home ->
+            "/"
+
+        Counter ->
+            "/counter"
+`
+}
+
+func (g *ViteElmGenerator) homePageElmTemplate() string {
+	return fmt.Sprintf(`module Page.Home exposing (Model, Msg, init, update, view)
+
+import Html exposing (Html, a, div, h1, input, p, text)
+import Html.Attributes exposing (class, href, placeholder, value)
+import Html.Events exposing (onInput)
+import Route
+
+
+-- MODEL
+
+
+type alias Model =
+    { name : String
+    }
+
+
+init : Model
+init =
+    { name = ""
+    }
+
+
+-- UPDATE
+
+
+type Msg
+    = NameChanged String
+
+
+update : Msg -> Model -> Model
+update msg model =
+    case msg of
+        NameChanged name ->
+            { model | name = name }
+
+
+-- VIEW
+
+
+view : Model -> Html Msg
+view model =
+    div [ class "%s w-full max-w-md" ]
+        [ h1 [ class "text-3xl font-bold mb-6" ]
+            [ text (greeting model.name) ]
+        , input
+            [ class "w-full px-4 py-2 bg-surface border border-line rounded-control"
+            , placeholder "Your name"
+            , value model.name
+            , onInput NameChanged
+            ]
+            []
+        , p [ class "mt-6 text-muted" ]
+            [ text "Try the "
+            , a [ Route.href Route.Counter, class "text-brand hover:underline" ] [ text "counter" ]
+            , text " or a "
+            , a [ href "/missing", class "text-brand hover:underline" ] [ text "page that doesn't exist" ]
+            , text "."
+            ]
+        ]
+
+
+greeting : String -> String
+greeting name =
+    if String.isEmpty (String.trim name) then
+        "Hello!"
+
+    else
+        "Hello, " ++ String.trim name ++ "!"
+`, g.counterStyles().card)
+}
+
+func (g *ViteElmGenerator) counterPageElmTemplate() string {
+	styles := g.counterStyles()
+
+	return fmt.Sprintf(`module Page.Counter exposing (Model, Msg(..), init, update, view)
+
+import Html exposing (Html, button, div, h1, text)
+import Html.Attributes exposing (class)
+import Html.Events exposing (onClick)
+
+
+-- MODEL
+
+
+type alias Model =
+    { count : Int
+    }
+
+
+init : Model
+init =
+    { count = 0
+    }
+
+
+-- UPDATE
+
+
+type Msg
+    = Increment
+    | Decrement
+
+
+update : Msg -> Model -> Model
+update msg model =
+    case msg of
+        Increment ->
+            { model | count = model.count + 1 }
+
+        Decrement ->
+            { model | count = model.count - 1 }
+
+
+-- VIEW
+
+
+view : Model -> Html Msg
+view model =
+    div [ class "%s" ]
+        [ h1 [ class "text-3xl font-bold text-center mb-6" ]
+            [ text "Counter" ]
+        , div [ class "flex items-center justify-center gap-4" ]
+            [ button
+                [ onClick Decrement
+                , class "%s"
+                ]
+                [ text "-" ]
+            , div [ class "text-2xl font-mono w-16 text-center" ]
+                [ text (String.fromInt model.count) ]
+            , button
+                [ onClick Increment
+                , class "%s"
+                ]
+                [ text "+" ]
+            ]
+        ]
+`, styles.card, styles.decrement, styles.increment)
+}
+
+func (g *ViteElmGenerator) notFoundPageElmTemplate() string {
+	return `module Page.NotFound exposing (view)
+
+import Html exposing (Html, a, div, h1, p, text)
+import Html.Attributes exposing (class)
+import Route
+
+
+view : Html msg
+view =
+    div [ class "text-center" ]
+        [ h1 [ class "text-6xl font-bold text-line" ] [ text "404" ]
+        , p [ class "mt-4 text-muted" ] [ text "This page doesn't exist." ]
+        , a [ Route.href Route.Home, class "mt-6 inline-block text-brand hover:underline" ]
+            [ text "Back home" ]
+        ]
+`
+}
+
+func (g *ViteElmGenerator) appReadmeTemplate() string {
+	return fmt.Sprintf(`
+## Routing
+
+The app is a `+"`Browser.application`"+`: `+"`src/Route.elm`"+` maps URLs to pages with
+`+"`Url.Parser`"+`, and each page in `+"`src/Page/`"+` has its own `+"`Model`"+`, `+"`Msg`"+`,
+`+"`update`"+` and `+"`view`"+`. URLs matching no route show `+"`Page.NotFound`"+`.
+
+To add a page, add a constructor to `+"`Route`"+` and its path to the parser and
+`+"`toPath`"+`, then a module in `+"`src/Page/`"+` and a case for it in `+"`Main.elm`"+`.
+
+Vite serves `+"`index.html`"+` for every route in development and `+"`vite preview`"+`.
+In production, configure the web server the same way, so reloading `+"`%s`"+`
+doesn't return a 404.
+`, "/counter")
+}
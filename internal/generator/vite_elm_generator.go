@@ -6,14 +6,108 @@ import (
 	"path/filepath"
 )
 
-type ViteElmGenerator struct{}
+type ViteElmGenerator struct {
+	// apiProxy, when set, makes the Vite dev server forward /api requests
+	// to this backend URL
+	apiProxy string
+	// packs are the feature packs layered on top of the project
+	packs []*pack
+	// runner writes the project's dev, test, build, lint and clean tasks
+	runner taskRunner
+	// versions are the dependency versions the templates use
+	versions Catalog
+	// app generates a Browser.application with routing instead of the
+	// Browser.sandbox counter
+	app bool
+	// interop generates a Browser.element counter with flags and ports
+	interop bool
+	// lint adds an elm-review configuration and a lint script
+	lint bool
+	// ts generates main.ts and a Vite config in TypeScript, type-checked
+	// against a declaration of Main.elm
+	ts bool
+	// darkMode adds a class-based dark variant to the theme and a toggle
+	// to the Elm views
+	darkMode bool
+	// components adds a components layer to style.css, which the Elm views
+	// use instead of utilities
+	components bool
+}
 
 func NewViteElmGenerator() *ViteElmGenerator {
-	return &ViteElmGenerator{}
+	return &ViteElmGenerator{versions: DefaultCatalog()}
+}
+
+// WithPacks selects feature packs by name, e.g. "ci-github". Packs they
+// depend on are added as well.
+func (g *ViteElmGenerator) WithPacks(names ...string) error {
+	packs, err := resolvePacks(builtinPacks, "vite-elm", names)
+	if err != nil {
+		return err
+	}
+	g.packs = packs
+	return nil
+}
+
+// WithVersions overrides dependency versions of the embedded catalog, see
+// Catalog.Override
+func (g *ViteElmGenerator) WithVersions(overrides Catalog) error {
+	versions, err := DefaultCatalog().Override(overrides)
+	if err != nil {
+		return err
+	}
+	g.versions = versions
+	return nil
+}
+
+// UseApp generates a Browser.application with Url.Parser routing, a page
+// module per route and a 404 page, instead of the single-page counter
+func (g *ViteElmGenerator) UseApp() {
+	g.app = true
+}
+
+// UseInterop generates a Browser.element counter that gets typed flags from
+// main.js and saves its state to localStorage and logs through ports
+func (g *ViteElmGenerator) UseInterop() {
+	g.interop = true
+}
+
+// UseLint adds an elm-review configuration in review/ that reports unused
+// and debug code, and an npm lint script running it
+func (g *ViteElmGenerator) UseLint() {
+	g.lint = true
+}
+
+// UseTypeScript generates src/main.ts, vite.config.ts, a tsconfig.json and
+// src/Main.elm.d.ts declaring the flags and ports of Main.elm, and type-checks
+// with tsc before building
+func (g *ViteElmGenerator) UseTypeScript() {
+	g.ts = true
+}
+
+// UseDarkMode adds a dark variant driven by a dark class, dark values of
+// the colour tokens, and a toggle in the Elm app that sets the class
+func (g *ViteElmGenerator) UseDarkMode() {
+	g.darkMode = true
+}
+
+// UseComponents adds a components layer with card and button classes built
+// from the design tokens, and uses them in the Elm views
+func (g *ViteElmGenerator) UseComponents() {
+	g.components = true
+}
+
+// UseMakefile writes the project tasks to a Makefile instead of Taskfile.yml
+func (g *ViteElmGenerator) UseMakefile() {
+	g.runner.makefile = true
 }
 
 // Generate creates a new Vite + Elm + Tailwind project
 func (g *ViteElmGenerator) Generate(projectName string) error {
+	if g.app && g.interop {
+		return fmt.Errorf("the application and interop templates can't be combined")
+	}
+
 	// Check if directory already exists
 	if _, err := os.Stat(projectName); err == nil {
 		return fmt.Errorf("directory '%s' already exists", projectName)
@@ -29,38 +123,73 @@ func (g *ViteElmGenerator) Generate(projectName string) error {
 
 // createStructure creates all project files and directories
 func (g *ViteElmGenerator) createStructure(projectName string) error {
-	// Create directories
-	dirs := []string{
-		projectName,
-		filepath.Join(projectName, "src"),
-		filepath.Join(projectName, "public"),
+	dirs, files := g.layout(projectName)
+	if err := applyPacks(g.packTarget(projectName, files), g.packs); err != nil {
+		return err
+	}
+
+	return writeProject(projectName, dirs, files)
+}
+
+// packTarget returns the target feature packs are applied to, for the files
+// of layout(projectName)
+func (g *ViteElmGenerator) packTarget(projectName string, files map[string]string) *packTarget {
+	return &packTarget{
+		kind:      "vite-elm",
+		shortName: filepath.Base(projectName),
+		files:     files,
+		versions:  &g.versions,
 	}
+}
 
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
-		}
+// layout returns the directories and files of a Vite + Elm project, relative
+// to the project root
+func (g *ViteElmGenerator) layout(projectName string) ([]string, map[string]string) {
+	// The directory may be a path; package.json and the page title only
+	// want its last element
+	name := filepath.Base(projectName)
+
+	dirs := []string{
+		"src",
+		"public",
+		"tests",
 	}
 
-	// Create files
 	files := map[string]string{
-		filepath.Join(projectName, "package.json"):     g.packageJSONTemplate(projectName),
-		filepath.Join(projectName, "vite.config.js"):   g.viteConfigTemplate(),
-		filepath.Join(projectName, "index.html"):       g.indexHTMLTemplate(projectName),
-		filepath.Join(projectName, "src", "main.js"):   g.mainJsTemplate(),
-		filepath.Join(projectName, "src", "style.css"): g.styleCSSTemplate(),
-		filepath.Join(projectName, "src", "Main.elm"):  g.mainElmTemplate(),
-		filepath.Join(projectName, "elm.json"):         g.elmJSONTemplate(),
-		filepath.Join(projectName, "elm-tooling.json"): g.elmToolingJSONTemplate(),
-		filepath.Join(projectName, ".gitignore"):       g.gitignoreTemplate(),
-		filepath.Join(projectName, "README.md"):        g.readmeTemplate(projectName),
+		"package.json":                       g.packageJSONTemplate(name),
+		g.viteConfigName():                   g.viteConfigTemplate(),
+		"index.html":                         g.indexHTMLTemplate(name),
+		filepath.Join("src", g.scriptName()): g.mainJsTemplate(),
+		filepath.Join("src", "style.css"):    g.styleCSSTemplate(),
+		filepath.Join("src", "Main.elm"):     g.mainElmTemplate(),
+		"elm.json":                           g.elmJSONTemplate(),
+		"elm-tooling.json":                   g.elmToolingJSONTemplate(),
+		".gitignore":                         g.gitignoreTemplate(),
+		"README.md":                          g.readmeTemplate(name),
 	}
-
-	for path, content := range files {
-		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
-			return fmt.Errorf("failed to create file %s: %w", path, err)
-		}
+	if g.app {
+		dirs = append(dirs, filepath.Join("src", "Page"), filepath.Join("tests", "Page"))
+		files[filepath.Join("src", "Main.elm")] = g.appMainElmTemplate(name)
+		files[filepath.Join("src", "Route.elm")] = g.routeElmTemplate()
+		files[filepath.Join("src", "Page", "Home.elm")] = g.homePageElmTemplate()
+		files[filepath.Join("src", "Page", "Counter.elm")] = g.counterPageElmTemplate()
+		files[filepath.Join("src", "Page", "NotFound.elm")] = g.notFoundPageElmTemplate()
+	}
+	if g.interop {
+		files[filepath.Join("src", "Main.elm")] = g.interopMainElmTemplate()
+		files[filepath.Join("src", g.scriptName())] = g.interopMainJsTemplate(name)
+	}
+	if g.ts {
+		files["tsconfig.json"] = g.tsconfigTemplate()
+		files[filepath.Join("src", "Main.elm.d.ts")] = g.elmDeclarationTemplate()
 	}
+	if g.lint {
+		dirs = append(dirs, filepath.Join("review", "src"))
+		files[filepath.Join("review", "elm.json")] = g.reviewElmJSONTemplate()
+		files[filepath.Join("review", "src", "ReviewConfig.elm")] = g.reviewConfigTemplate()
+	}
+	files[filepath.FromSlash(g.counterTestPath())] = g.counterTestTemplate()
+	files[g.runner.filename()] = g.runner.render("dev", g.tasks())
 
-	return nil
+	return dirs, files
 }
@@ -2,8 +2,12 @@ package generator
 
 import (
 	"encoding/json"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -190,6 +194,23 @@ func TestViteElmGenerator_ViteConfig(t *testing.T) {
 	})
 }
 
+func TestViteElmGenerator_ViteConfigProxy(t *testing.T) {
+	t.Run("has no proxy by default", func(t *testing.T) {
+		gen := NewViteElmGenerator()
+		if strings.Contains(gen.viteConfigTemplate(), "proxy") {
+			t.Error("vite.config.js shouldn't configure a proxy by default")
+		}
+	})
+
+	t.Run("proxies api when configured", func(t *testing.T) {
+		gen := NewViteElmGenerator()
+		gen.apiProxy = "http://localhost:8080"
+		if !strings.Contains(gen.viteConfigTemplate(), "'/api': 'http://localhost:8080'") {
+			t.Error("vite.config.js doesn't proxy /api")
+		}
+	})
+}
+
 func TestViteElmGenerator_StyleCss(t *testing.T) {
 	gen := NewViteElmGenerator()
 	content := gen.styleCSSTemplate()
@@ -252,7 +273,7 @@ func TestViteElmGenerator_MainElm(t *testing.T) {
 	content := gen.mainElmTemplate()
 
 	t.Run("declares Main module", func(t *testing.T) {
-		if !strings.Contains(content, "module Main exposing (main)") {
+		if !strings.Contains(content, "module Main exposing (Msg(..), init, main, update)") {
 			t.Error("Main.elm doesn't declare Main module")
 		}
 	})
@@ -275,8 +296,8 @@ func TestViteElmGenerator_MainElm(t *testing.T) {
 		}
 	})
 
-	t.Run("uses Tailwind classes", func(t *testing.T) {
-		tailwindClasses := []string{"bg-gray-100", "rounded", "hover:bg"}
+	t.Run("uses the design tokens", func(t *testing.T) {
+		tailwindClasses := []string{"bg-canvas", "rounded-card", "hover:bg-danger-hover"}
 		for _, class := range tailwindClasses {
 			if !strings.Contains(content, class) {
 				t.Errorf("Main.elm doesn't use Tailwind class: %s", class)
@@ -340,7 +361,7 @@ func TestViteElmGenerator_ElmToolingJson(t *testing.T) {
 			t.Fatal("tools field is not a map")
 		}
 
-		requiredTools := []string{"elm", "elm-format", "elm-json"}
+		requiredTools := []string{"elm", "elm-format", "elm-json", "elm-test-rs"}
 		for _, tool := range requiredTools {
 			if _, exists := tools[tool]; !exists {
 				t.Errorf("Missing required tool: %s", tool)
@@ -403,3 +424,595 @@ func TestViteElmGenerator_Readme(t *testing.T) {
 		}
 	})
 }
+
+// elmPackages maps the first segment of imported module names to the
+// package that provides them, for modules outside elm/core
+var elmPackages = map[string]string{
+	"Browser": "elm/browser",
+	"Html":    "elm/html",
+	"Url":     "elm/url",
+	"Json":    "elm/json",
+	"Expect":  "elm-explorations/test",
+	"Fuzz":    "elm-explorations/test",
+	"Test":    "elm-explorations/test",
+}
+
+// checkElmModules checks that the Elm modules in files under srcDir are
+// named after their paths, and that their imports are either modules in
+// files or come from a direct dependency in elmJSON. Modules in a tests
+// directory may also import the source modules and the test dependencies.
+func checkElmModules(t *testing.T, files map[string]string, srcDir, elmJSON string) {
+	t.Helper()
+
+	type dependencies struct {
+		Direct map[string]string `json:"direct"`
+	}
+	var project struct {
+		Dependencies     dependencies `json:"dependencies"`
+		TestDependencies dependencies `json:"test-dependencies"`
+	}
+	if err := json.Unmarshal([]byte(files[elmJSON]), &project); err != nil {
+		t.Fatalf("%s is invalid JSON: %v", elmJSON, err)
+	}
+
+	dirs := []string{srcDir}
+	if path.Base(srcDir) == "tests" {
+		dirs = append(dirs, path.Join(path.Dir(srcDir), "src"))
+		maps.Copy(project.Dependencies.Direct, project.TestDependencies.Direct)
+	}
+	modules := map[string]bool{}
+	for name := range files {
+		for _, dir := range dirs {
+			if rel, ok := strings.CutPrefix(filepath.ToSlash(name), dir+"/"); ok && strings.HasSuffix(rel, ".elm") {
+				modules[strings.ReplaceAll(strings.TrimSuffix(rel, ".elm"), "/", ".")] = true
+			}
+		}
+	}
+
+	for name, content := range files {
+		rel, ok := strings.CutPrefix(filepath.ToSlash(name), srcDir+"/")
+		if !ok || !strings.HasSuffix(rel, ".elm") {
+			continue
+		}
+		module := strings.ReplaceAll(strings.TrimSuffix(rel, ".elm"), "/", ".")
+		if !strings.HasPrefix(content, "module "+module+" exposing") && !strings.HasPrefix(content, "port module "+module+" exposing") {
+			t.Errorf("%s doesn't declare module %s", name, module)
+		}
+
+		for _, line := range strings.Split(content, "\n") {
+			imported, ok := strings.CutPrefix(line, "import ")
+			if !ok {
+				continue
+			}
+			imported = strings.Fields(imported)[0]
+			if modules[imported] {
+				continue
+			}
+			pkg, ok := elmPackages[strings.Split(imported, ".")[0]]
+			if !ok {
+				continue // elm/core
+			}
+			if _, ok := project.Dependencies.Direct[pkg]; !ok {
+				t.Errorf("%s imports %s, but %s isn't a direct dependency in %s", name, imported, pkg, elmJSON)
+			}
+		}
+
+		for _, unused := range unusedElmImports(content) {
+			t.Errorf("%s imports %s without using it", name, unused)
+		}
+	}
+}
+
+// unusedElmImports roughly checks what elm-review's NoUnused.Variables
+// reports about imports: it returns the imported modules and exposed names
+// that don't occur in the rest of the module
+func unusedElmImports(content string) []string {
+	var imports, code []string
+	for _, line := range strings.Split(content, "\n") {
+		if imported, ok := strings.CutPrefix(line, "import "); ok {
+			imports = append(imports, imported)
+		} else if !strings.HasPrefix(line, "module ") && !strings.HasPrefix(line, "port module ") {
+			code = append(code, line)
+		}
+	}
+	body := strings.Join(code, "\n")
+	used := func(pattern string) bool {
+		return regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(pattern)).MatchString(body)
+	}
+
+	var unused []string
+	for _, imported := range imports {
+		module, exposing, _ := strings.Cut(imported, " exposing ")
+		fields := strings.Fields(module)
+		switch {
+		case len(fields) == 3 && fields[1] == "as":
+			if !used(fields[2] + ".") {
+				unused = append(unused, fields[0]+" as "+fields[2])
+			}
+		case exposing == "":
+			if !used(fields[0] + ".") {
+				unused = append(unused, fields[0])
+			}
+		}
+		exposing = strings.TrimSuffix(strings.TrimPrefix(exposing, "("), ")")
+		for _, exposed := range strings.Split(exposing, ",") {
+			exposed = strings.TrimSuffix(strings.TrimSpace(exposed), "(..)")
+			if exposed != "" && !regexp.MustCompile(`\b`+regexp.QuoteMeta(exposed)+`\b`).MatchString(body) {
+				unused = append(unused, fields[0]+"."+exposed)
+			}
+		}
+	}
+	return unused
+}
+
+func TestViteElmGenerator_App(t *testing.T) {
+	gen := NewViteElmGenerator()
+	gen.UseApp()
+	dirs, files := gen.layout("my-app")
+
+	t.Run("has a module per page", func(t *testing.T) {
+		for _, name := range []string{"src/Main.elm", "src/Route.elm", "src/Page/Home.elm", "src/Page/Counter.elm", "src/Page/NotFound.elm"} {
+			if _, ok := files[filepath.FromSlash(name)]; !ok {
+				t.Errorf("%s not created", name)
+			}
+		}
+		if !slices.Contains(dirs, filepath.Join("src", "Page")) {
+			t.Error("src/Page isn't created")
+		}
+		checkElmModules(t, files, "src", "elm.json")
+	})
+
+	t.Run("routes with Browser.application", func(t *testing.T) {
+		main := files[filepath.Join("src", "Main.elm")]
+		for _, want := range []string{"Browser.application", "onUrlChange = UrlChanged", "NotFoundPage", `"Counter · my-app"`} {
+			if !strings.Contains(main, want) {
+				t.Errorf("Main.elm doesn't contain %q", want)
+			}
+		}
+		if route := files[filepath.Join("src", "Route.elm")]; !strings.Contains(route, `Parser.map Counter (s "counter")`) {
+			t.Errorf("Route.elm doesn't parse /counter:\n%s", route)
+		}
+	})
+
+	t.Run("lets Elm own the body", func(t *testing.T) {
+		if strings.Contains(files["index.html"], `id="app"`) {
+			t.Error("index.html has a mount point Browser.application doesn't use")
+		}
+		if !strings.Contains(files[filepath.Join("src", "main.js")], "Main.init()") {
+			t.Error("main.js doesn't start the application")
+		}
+	})
+
+	t.Run("falls back to index.html", func(t *testing.T) {
+		if !strings.Contains(files["vite.config.js"], "appType: 'spa'") {
+			t.Errorf("vite.config.js doesn't serve index.html for routes:\n%s", files["vite.config.js"])
+		}
+	})
+
+	t.Run("documents routing", func(t *testing.T) {
+		if !strings.Contains(files["README.md"], "## Routing") {
+			t.Error("README doesn't document routing")
+		}
+	})
+}
+
+func TestViteElmGenerator_ElmModules(t *testing.T) {
+	_, files := NewViteElmGenerator().layout("my-app")
+	checkElmModules(t, files, "src", "elm.json")
+}
+
+func TestViteElmGenerator_Interop(t *testing.T) {
+	gen := NewViteElmGenerator()
+	gen.UseInterop()
+	_, files := gen.layout("my-app")
+
+	mainElm := files[filepath.Join("src", "Main.elm")]
+	mainJs := files[filepath.Join("src", "main.js")]
+
+	t.Run("is a port module with typed flags", func(t *testing.T) {
+		for _, want := range []string{"port module Main exposing (Model, Msg(..), init, main, update)", "Browser.element", "main : Program Flags Model Msg"} {
+			if !strings.Contains(mainElm, want) {
+				t.Errorf("Main.elm doesn't contain %q", want)
+			}
+		}
+		for _, f := range interopFlags {
+			if !strings.Contains(mainElm, f.name+" : "+f.elmType) {
+				t.Errorf("Flags in Main.elm has no %s : %s", f.name, f.elmType)
+			}
+			if !strings.Contains(mainJs, f.name+":") {
+				t.Errorf("main.js doesn't pass the %s flag", f.name)
+			}
+		}
+		checkElmModules(t, files, "src", "elm.json")
+	})
+
+	t.Run("main.js uses every port", func(t *testing.T) {
+		for _, p := range interopPorts {
+			cmd := "Cmd msg"
+			use := "app.ports." + p.name + ".subscribe("
+			if p.incoming {
+				cmd = "Sub msg"
+				use = "app.ports." + p.name + ".send("
+			}
+			if !strings.Contains(mainElm, "port "+p.name+" : ") || !strings.Contains(mainElm, cmd) {
+				t.Errorf("Main.elm doesn't declare port %s", p.name)
+			}
+			if !strings.Contains(mainJs, use) {
+				t.Errorf("main.js doesn't contain %s", use)
+			}
+		}
+		if !strings.Contains(mainJs, "'my-app:state'") {
+			t.Error("main.js doesn't save under a project specific key")
+		}
+	})
+
+	t.Run("documents the contract", func(t *testing.T) {
+		readme := files["README.md"]
+		for _, want := range []string{"## JavaScript interop", "| `apiBaseUrl` | `String` |", "| `stateChanged` | JS → Elm |"} {
+			if !strings.Contains(readme, want) {
+				t.Errorf("README doesn't contain %q", want)
+			}
+		}
+	})
+
+	t.Run("can't be combined with the application", func(t *testing.T) {
+		gen.UseApp()
+		err := gen.Generate(filepath.Join(t.TempDir(), "my-app"))
+		if err == nil || !strings.Contains(err.Error(), "can't be combined") {
+			t.Errorf("expected an error, got %v", err)
+		}
+	})
+}
+
+func TestViteElmGenerator_Tests(t *testing.T) {
+	for _, tc := range []struct {
+		name, testFile, tested string
+		configure              func(*ViteElmGenerator)
+	}{
+		{"counter", "tests/MainTest.elm", "Main", func(*ViteElmGenerator) {}},
+		{"app", "tests/Page/CounterTest.elm", "Page.Counter", (*ViteElmGenerator).UseApp},
+		{"interop", "tests/MainTest.elm", "Main", (*ViteElmGenerator).UseInterop},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gen := NewViteElmGenerator()
+			tc.configure(gen)
+			_, files := gen.layout("my-app")
+
+			tests, ok := files[filepath.FromSlash(tc.testFile)]
+			if !ok {
+				t.Fatalf("%s not created", tc.testFile)
+			}
+			for _, want := range []string{"import " + tc.tested + " exposing (", "Msg(..)", "fuzz2 Fuzz.int (Fuzz.list msgFuzzer)", `test "Increment adds one"`} {
+				if !strings.Contains(tests, want) {
+					t.Errorf("%s doesn't contain %q", tc.testFile, want)
+				}
+			}
+			checkElmModules(t, files, "tests", "elm.json")
+
+			var project struct {
+				TestDependencies struct {
+					Direct   map[string]string `json:"direct"`
+					Indirect map[string]string `json:"indirect"`
+				} `json:"test-dependencies"`
+			}
+			if err := json.Unmarshal([]byte(files["elm.json"]), &project); err != nil {
+				t.Fatalf("elm.json is invalid JSON: %v", err)
+			}
+			if project.TestDependencies.Direct["elm-explorations/test"] != "2.2.0" {
+				t.Errorf("elm-explorations/test isn't a test dependency: %v", project.TestDependencies.Direct)
+			}
+			for _, pkg := range []string{"elm/bytes", "elm/random"} {
+				if _, ok := project.TestDependencies.Indirect[pkg]; !ok {
+					t.Errorf("elm.json doesn't list %s, which elm-explorations/test needs", pkg)
+				}
+			}
+		})
+	}
+
+	t.Run("npm test runs the installed elm-test-rs", func(t *testing.T) {
+		_, files := NewViteElmGenerator().layout("my-app")
+		if !strings.Contains(files["package.json"], `"test": "elm-test-rs"`) {
+			t.Errorf("package.json doesn't run elm-test-rs:\n%s", files["package.json"])
+		}
+		if !strings.Contains(files["elm-tooling.json"], `"elm-test-rs": "3.0.0"`) {
+			t.Errorf("elm-tooling.json doesn't install elm-test-rs:\n%s", files["elm-tooling.json"])
+		}
+		if !strings.Contains(files["Taskfile.yml"], "elm-format --validate src tests") {
+			t.Error("lint doesn't check the formatting of the tests")
+		}
+	})
+}
+
+func TestViteElmGenerator_Lint(t *testing.T) {
+	t.Run("not configured by default", func(t *testing.T) {
+		_, files := NewViteElmGenerator().layout("my-app")
+		if _, ok := files[filepath.Join("review", "elm.json")]; ok {
+			t.Error("review/elm.json created without UseLint")
+		}
+		if strings.Contains(files["package.json"], "elm-review") {
+			t.Error("package.json depends on elm-review without UseLint")
+		}
+	})
+
+	gen := NewViteElmGenerator()
+	gen.UseLint()
+	_, files := gen.layout("my-app")
+
+	t.Run("configures the standard rules", func(t *testing.T) {
+		checkElmModules(t, files, "review/src", filepath.Join("review", "elm.json"))
+		config := files[filepath.Join("review", "src", "ReviewConfig.elm")]
+		for _, rule := range reviewRules {
+			if !strings.Contains(config, "import "+rule+"\n") || !strings.Contains(config, rule+".rule") {
+				t.Errorf("ReviewConfig.elm doesn't enable %s", rule)
+			}
+		}
+
+		var review struct {
+			SourceDirectories []string `json:"source-directories"`
+			Dependencies      struct {
+				Direct map[string]string `json:"direct"`
+			} `json:"dependencies"`
+		}
+		if err := json.Unmarshal([]byte(files[filepath.Join("review", "elm.json")]), &review); err != nil {
+			t.Fatalf("review/elm.json is invalid JSON: %v", err)
+		}
+		for _, pkg := range []string{"jfmengels/elm-review", "jfmengels/elm-review-unused", "jfmengels/elm-review-debug"} {
+			if _, ok := review.Dependencies.Direct[pkg]; !ok {
+				t.Errorf("review/elm.json doesn't depend on %s", pkg)
+			}
+		}
+		if !slices.Equal(review.SourceDirectories, []string{"src"}) {
+			t.Errorf("unexpected source-directories %v", review.SourceDirectories)
+		}
+	})
+
+	t.Run("adds the lint script", func(t *testing.T) {
+		var pkg struct {
+			Scripts         map[string]string `json:"scripts"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		if err := json.Unmarshal([]byte(files["package.json"]), &pkg); err != nil {
+			t.Fatalf("package.json is invalid JSON: %v", err)
+		}
+		if pkg.Scripts["lint"] != "elm-review" {
+			t.Errorf("expected an elm-review lint script, got %q", pkg.Scripts["lint"])
+		}
+		if _, ok := pkg.DevDependencies["elm-review"]; !ok {
+			t.Error("elm-review isn't a devDependency")
+		}
+		if !strings.Contains(files["Taskfile.yml"], "npm run lint") {
+			t.Error("the lint task doesn't run elm-review")
+		}
+		if !strings.Contains(files["README.md"], "## Linting") {
+			t.Error("README doesn't document linting")
+		}
+	})
+
+	t.Run("runs in CI", func(t *testing.T) {
+		gen := NewViteElmGenerator()
+		gen.UseLint()
+		if err := gen.WithPacks("ci-github"); err != nil {
+			t.Fatal(err)
+		}
+		projectName := filepath.Join(t.TempDir(), "my-app")
+		if err := gen.Generate(projectName); err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		workflow, err := os.ReadFile(filepath.Join(projectName, ".github", "workflows", "ci.yml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(workflow), "npm run lint") {
+			t.Errorf("the workflow doesn't run elm-review:\n%s", workflow)
+		}
+	})
+}
+
+func TestViteElmGenerator_TypeScript(t *testing.T) {
+	gen := NewViteElmGenerator()
+	gen.UseTypeScript()
+	gen.UseInterop()
+	_, files := gen.layout("my-app")
+
+	t.Run("replaces the JavaScript files", func(t *testing.T) {
+		for _, name := range []string{"src/main.ts", "src/Main.elm.d.ts", "tsconfig.json", "vite.config.ts"} {
+			if _, ok := files[filepath.FromSlash(name)]; !ok {
+				t.Errorf("%s not created", name)
+			}
+		}
+		for _, name := range []string{"src/main.js", "vite.config.js"} {
+			if _, ok := files[filepath.FromSlash(name)]; ok {
+				t.Errorf("%s created alongside the TypeScript files", name)
+			}
+		}
+		if !strings.Contains(files["index.html"], `src="/src/main.ts"`) {
+			t.Error("index.html doesn't load main.ts")
+		}
+	})
+
+	t.Run("type-checks before building", func(t *testing.T) {
+		var pkg struct {
+			Scripts         map[string]string `json:"scripts"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		if err := json.Unmarshal([]byte(files["package.json"]), &pkg); err != nil {
+			t.Fatalf("package.json is invalid JSON: %v", err)
+		}
+		if pkg.Scripts["build"] != "tsc && vite build" {
+			t.Errorf("build script doesn't run tsc: %q", pkg.Scripts["build"])
+		}
+		if _, ok := pkg.DevDependencies["typescript"]; !ok {
+			t.Error("typescript isn't a devDependency")
+		}
+
+		var tsconfig struct {
+			CompilerOptions map[string]any `json:"compilerOptions"`
+			Include         []string       `json:"include"`
+		}
+		if err := json.Unmarshal([]byte(files["tsconfig.json"]), &tsconfig); err != nil {
+			t.Fatalf("tsconfig.json is invalid JSON: %v", err)
+		}
+		if tsconfig.CompilerOptions["strict"] != true || tsconfig.CompilerOptions["noEmit"] != true {
+			t.Errorf("tsconfig.json should be strict and not emit: %v", tsconfig.CompilerOptions)
+		}
+	})
+
+	t.Run("declares the flags and ports", func(t *testing.T) {
+		declaration := files[filepath.Join("src", "Main.elm.d.ts")]
+		for _, f := range interopFlags {
+			if !strings.Contains(declaration, "  "+f.name+": "+f.tsType+"\n") {
+				t.Errorf("Main.elm.d.ts doesn't declare the %s flag", f.name)
+			}
+		}
+		for _, p := range interopPorts {
+			want := p.name + ": {\n    subscribe(callback: (value: " + p.tsType + ") => void): void"
+			if p.incoming {
+				want = p.name + ": { send(value: " + p.tsType + "): void }"
+			}
+			if !strings.Contains(declaration, want) {
+				t.Errorf("Main.elm.d.ts doesn't declare the %s port", p.name)
+			}
+		}
+		if !strings.Contains(declaration, "init(options: { node: HTMLElement | null; flags: Flags }): App") {
+			t.Errorf("Main.elm.d.ts doesn't declare Main.init with flags:\n%s", declaration)
+		}
+	})
+
+	t.Run("declares only what Main.elm has", func(t *testing.T) {
+		for _, tc := range []struct {
+			name      string
+			configure func(*ViteElmGenerator)
+			init      string
+		}{
+			{"counter", func(*ViteElmGenerator) {}, "init(options: { node: HTMLElement | null }): App"},
+			{"app", (*ViteElmGenerator).UseApp, "init(): App"},
+		} {
+			gen := NewViteElmGenerator()
+			gen.UseTypeScript()
+			tc.configure(gen)
+			_, files := gen.layout("my-app")
+
+			declaration := files[filepath.Join("src", "Main.elm.d.ts")]
+			if !strings.Contains(declaration, tc.init) {
+				t.Errorf("%s: Main.elm.d.ts doesn't declare %s:\n%s", tc.name, tc.init, declaration)
+			}
+			if strings.Contains(declaration, "interface Flags") || strings.Contains(declaration, "ports: Ports") {
+				t.Errorf("%s: Main.elm.d.ts declares flags or ports Main.elm doesn't have:\n%s", tc.name, declaration)
+			}
+		}
+	})
+}
+
+// paletteClass matches Tailwind classes of the default palette, which the
+// Elm views use the design tokens instead of
+var paletteClass = regexp.MustCompile(`\b(bg|text|border)-(red|green|gray|blue)-\d+\b`)
+
+func TestViteElmGenerator_Theme(t *testing.T) {
+	variants := []struct {
+		name      string
+		configure func(*ViteElmGenerator)
+	}{
+		{"counter", func(*ViteElmGenerator) {}},
+		{"app", (*ViteElmGenerator).UseApp},
+		{"interop", (*ViteElmGenerator).UseInterop},
+	}
+
+	t.Run("defines the tokens", func(t *testing.T) {
+		css := NewViteElmGenerator().styleCSSTemplate()
+		for _, group := range themeTokens {
+			for _, token := range group.tokens {
+				if !strings.Contains(css, "  "+token.name+": "+token.value+";") {
+					t.Errorf("style.css doesn't define %s", token.name)
+				}
+			}
+		}
+		for _, unwanted := range []string{"@custom-variant", ".dark", "@layer components"} {
+			if strings.Contains(css, unwanted) {
+				t.Errorf("style.css contains %q by default", unwanted)
+			}
+		}
+	})
+
+	for _, v := range variants {
+		t.Run(v.name+" views use the tokens", func(t *testing.T) {
+			for _, options := range []func(*ViteElmGenerator){func(*ViteElmGenerator) {}, (*ViteElmGenerator).UseDarkMode, (*ViteElmGenerator).UseComponents} {
+				gen := NewViteElmGenerator()
+				v.configure(gen)
+				options(gen)
+				_, files := gen.layout("my-app")
+
+				for name, content := range files {
+					if !strings.HasSuffix(name, ".elm") {
+						continue
+					}
+					if class := paletteClass.FindString(content); class != "" {
+						t.Errorf("%s uses %s instead of a design token", name, class)
+					}
+				}
+				checkElmModules(t, files, "src", "elm.json")
+				checkElmModules(t, files, "tests", "elm.json")
+			}
+		})
+	}
+
+	t.Run("dark mode", func(t *testing.T) {
+		gen := NewViteElmGenerator()
+		gen.UseDarkMode()
+		css := gen.styleCSSTemplate()
+		if !strings.Contains(css, "@custom-variant dark (&:where(.dark, .dark *));") {
+			t.Error("style.css doesn't define the class-based dark variant")
+		}
+		for _, group := range themeTokens {
+			for _, token := range group.tokens {
+				if token.dark != "" && !strings.Contains(css, "    "+token.name+": "+token.dark+";") {
+					t.Errorf("style.css doesn't switch %s in dark mode", token.name)
+				}
+			}
+		}
+
+		for _, v := range variants {
+			gen := NewViteElmGenerator()
+			v.configure(gen)
+			gen.UseDarkMode()
+			_, files := gen.layout("my-app")
+
+			main := files[filepath.Join("src", "Main.elm")]
+			for _, want := range []string{`classList [ ( "dark", model.dark ) ]`, "| ToggleDarkMode", "darkModeToggle model.dark", "onClick ToggleDarkMode"} {
+				if !strings.Contains(main, want) {
+					t.Errorf("%s: Main.elm doesn't contain %q", v.name, want)
+				}
+			}
+		}
+	})
+
+	t.Run("components", func(t *testing.T) {
+		gen := NewViteElmGenerator()
+		gen.UseComponents()
+		_, files := gen.layout("my-app")
+
+		css := files[filepath.Join("src", "style.css")]
+		for _, class := range []string{".card", ".btn", ".btn-danger", ".btn-success"} {
+			if !strings.Contains(css, "  "+class+" {") {
+				t.Errorf("the components layer doesn't define %s", class)
+			}
+		}
+		main := files[filepath.Join("src", "Main.elm")]
+		for _, class := range []string{`class "card"`, `class "btn btn-danger"`, `class "btn btn-success"`} {
+			if !strings.Contains(main, class) {
+				t.Errorf("Main.elm doesn't use %s", class)
+			}
+		}
+	})
+
+	t.Run("documents the tokens", func(t *testing.T) {
+		gen := NewViteElmGenerator()
+		gen.UseDarkMode()
+		gen.UseComponents()
+		readme := gen.readmeTemplate("my-app")
+		for _, want := range []string{"## Design tokens", "`dark` class", "`btn-danger`"} {
+			if !strings.Contains(readme, want) {
+				t.Errorf("README doesn't contain %q", want)
+			}
+		}
+	})
+}
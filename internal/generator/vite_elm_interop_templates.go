@@ -0,0 +1,319 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Templates of "vite-elm --interop": a Browser.element counter that gets
+// typed flags from main.js and talks to JavaScript through ports.
+
+// elmFlag is a field of the Flags record main.js passes to Main.init
+type elmFlag struct {
+	name    string
+	elmType string
+	// tsType is the type of the field in Main.elm.d.ts
+	tsType string
+	doc    string
+}
+
+// elmPort is a port of Main.elm
+type elmPort struct {
+	name string
+	// incoming ports carry values from JavaScript to Elm (send), the others
+	// from Elm to JavaScript (subscribe)
+	incoming bool
+	// elmType is the type of the values the port carries, tsType their type
+	// in Main.elm.d.ts
+	elmType string
+	tsType  string
+	doc     string
+}
+
+// interopFlags and interopPorts are the contract between Main.elm and
+// main.js, documented in the README
+var (
+	interopFlags = []elmFlag{
+		{name: "apiBaseUrl", elmType: "String", tsType: "string", doc: "base URL of the backend API, from `VITE_API_BASE_URL`"},
+		{name: "savedState", elmType: "Value", tsType: "unknown", doc: "state saved in localStorage, or `null` on the first visit"},
+	}
+	interopPorts = []elmPort{
+		{name: "saveState", elmType: "Value", tsType: "unknown", doc: "the state to save to localStorage"},
+		{name: "consoleLog", elmType: "{ level : String, message : String }", tsType: "{ level: string; message: string }", doc: "a message to log with `console.warn`, `console.error` or else `console.info`"},
+		{name: "stateChanged", incoming: true, elmType: "Value", tsType: "unknown", doc: "the state saved by another tab"},
+	}
+)
+
+func (g *ViteElmGenerator) interopMainElmTemplate() string {
+	styles := g.counterStyles()
+
+	var attributes, field, init, msg, update, root, toggle, toggleView string
+	if g.darkMode {
+		attributes = ", classList"
+		field = `
+    , dark : Bool`
+		init = `
+            , dark = False`
+		msg = `
+    | ToggleDarkMode`
+		update = `
+
+        ToggleDarkMode ->
+            ( { model | dark = not model.dark }, Cmd.none )`
+		root = `classList [ ( "dark", model.dark ) ], `
+		toggle = `
+            , darkModeToggle model.dark`
+		toggleView = darkModeToggleElm("mt-2 w-full text-sm text-muted hover:text-ink")
+	}
+
+	return fmt.Sprintf(`port module Main exposing (Model, Msg(..), init, main, update)
+
+import Browser
+import Html exposing (Html, button, div, h1, p, text)
+import Html.Attributes exposing (class%[1]s)
+import Html.Events exposing (onClick)
+import Json.Decode as Decode exposing (Decoder, Value)
+import Json.Encode as Encode
+
+
+-- PORTS
+
+
+{-| Save the state, which JavaScript writes to localStorage
+-}
+port saveState : Value -> Cmd msg
+
+
+{-| Log a message to the browser console, at level "info", "warn" or "error"
+-}
+port consoleLog : { level : String, message : String } -> Cmd msg
+
+
+{-| The state saved by the app in another tab
+-}
+port stateChanged : (Value -> msg) -> Sub msg
+
+
+-- MAIN
+
+
+{-| What JavaScript passes to Main.init, see the README
+-}
+type alias Flags =
+    { apiBaseUrl : String
+    , savedState : Value
+    }
+
+
+main : Program Flags Model Msg
+main =
+    Browser.element
+        { init = init
+        , view = view
+        , update = update
+        , subscriptions = subscriptions
+        }
+
+
+-- MODEL
+
+
+type alias Model =
+    { count : Int
+    , apiBaseUrl : String%[2]s
+    }
+
+
+init : Flags -> ( Model, Cmd Msg )
+init flags =
+    let
+        model =
+            { count = 0
+            , apiBaseUrl = flags.apiBaseUrl%[3]s
+            }
+    in
+    -- The saved state comes from outside Elm, so it's decoded rather than
+    -- trusted: a stale or edited one shouldn't crash the app
+    case Decode.decodeValue (Decode.nullable countDecoder) flags.savedState of
+        Ok (Just count) ->
+            ( { model | count = count }
+            , consoleLog { level = "info", message = "Restored count " ++ String.fromInt count }
+            )
+
+        Ok Nothing ->
+            ( model, Cmd.none )
+
+        Err err ->
+            ( model, consoleLog { level = "warn", message = "Ignoring saved state: " ++ Decode.errorToString err } )
+
+
+countDecoder : Decoder Int
+countDecoder =
+    Decode.field "count" Decode.int
+
+
+encode : Model -> Value
+encode model =
+    Encode.object
+        [ ( "count", Encode.int model.count )
+        ]
+
+
+-- UPDATE
+
+
+type Msg
+    = Increment
+    | Decrement
+    | StateChanged Value%[4]s
+
+
+update : Msg -> Model -> ( Model, Cmd Msg )
+update msg model =
+    case msg of
+        Increment ->
+            save { model | count = model.count + 1 }
+
+        Decrement ->
+            save { model | count = model.count - 1 }
+
+        StateChanged value ->
+            case Decode.decodeValue countDecoder value of
+                Ok count ->
+                    ( { model | count = count }, Cmd.none )
+
+                Err err ->
+                    ( model, consoleLog { level = "warn", message = "Ignoring saved state: " ++ Decode.errorToString err } )%[5]s
+
+
+save : Model -> ( Model, Cmd Msg )
+save model =
+    ( model, saveState (encode model) )
+
+
+-- SUBSCRIPTIONS
+
+
+subscriptions : Model -> Sub Msg
+subscriptions _ =
+    stateChanged StateChanged
+
+
+-- VIEW
+
+
+view : Model -> Html Msg
+view model =
+    div [ %[6]sclass "min-h-screen bg-canvas text-ink flex items-center justify-center" ]
+        [ div [ class "%[7]s" ]
+            [ h1 [ class "text-3xl font-bold text-center mb-6" ]
+                [ text "Elm + Vite + Tailwind" ]
+            , div [ class "flex items-center justify-center gap-4" ]
+                [ button
+                    [ onClick Decrement
+                    , class "%[8]s"
+                    ]
+                    [ text "-" ]
+                , div [ class "text-2xl font-mono w-16 text-center" ]
+                    [ text (String.fromInt model.count) ]
+                , button
+                    [ onClick Increment
+                    , class "%[9]s"
+                    ]
+                    [ text "+" ]
+                ]
+            , p [ class "mt-6 text-sm text-center text-muted" ]
+                [ text ("Saved in localStorage · API at " ++ model.apiBaseUrl) ]%[10]s
+            ]
+        ]
+%[11]s`, attributes, field, init, msg, update, root, styles.card, styles.decrement, styles.increment, toggle, toggleView)
+}
+
+func (g *ViteElmGenerator) interopMainJsTemplate(projectName string) string {
+	return fmt.Sprintf(`import './style.css'
+import Main from './Main.elm'
+
+// The localStorage key the Elm app saves its state under
+const storageKey = '%s:state'
+
+function loadState() {
+  try {
+    return JSON.parse(localStorage.getItem(storageKey) ?? 'null')
+  } catch {
+    return null
+  }
+}
+
+// Flags: the Flags record in Main.elm
+const app = Main.init({
+  node: document.getElementById('app'),
+  flags: {
+    apiBaseUrl: import.meta.env.VITE_API_BASE_URL ?? '/api',
+    savedState: loadState()
+  }
+})
+
+// Ports from Elm to JavaScript
+app.ports.saveState.subscribe((state) => {
+  localStorage.setItem(storageKey, JSON.stringify(state))
+})
+
+app.ports.consoleLog.subscribe(({ level, message }) => {
+  if (level === 'warn' || level === 'error') {
+    console[level](message)
+  } else {
+    console.info(message)
+  }
+})
+
+// Ports from JavaScript to Elm: the storage event fires when another tab
+// saves the state
+window.addEventListener('storage', (event) => {
+  const state = loadState()
+  if (event.key === storageKey && state !== null) {
+    app.ports.stateChanged.send(state)
+  }
+})
+`, projectName)
+}
+
+func (g *ViteElmGenerator) interopReadmeTemplate() string {
+	var flags, ports []string
+	for _, f := range interopFlags {
+		flags = append(flags, fmt.Sprintf("| `%s` | `%s` | %s |", f.name, f.elmType, f.doc))
+	}
+	for _, p := range interopPorts {
+		direction, use := "Elm → JS", "`app.ports."+p.name+".subscribe`"
+		if p.incoming {
+			direction, use = "JS → Elm", "`app.ports."+p.name+".send`"
+		}
+		ports = append(ports, fmt.Sprintf("| `%s` | %s | `%s` | %s: %s |", p.name, direction, p.elmType, use, p.doc))
+	}
+
+	return fmt.Sprintf(`
+## JavaScript interop
+
+`+"`src/Main.elm`"+` is a `+"`Browser.element`"+` that talks to `+"`src/%s`"+` only through
+flags and ports. Both sides have to agree on the names and types below; Elm
+checks the values at runtime and throws if JavaScript sends the wrong shape.
+
+Flags, passed once to `+"`Main.init`"+` as the `+"`Flags`"+` record:
+
+| Field | Elm type | Value |
+|-------|----------|-------|
+%s
+
+Ports:
+
+| Port | Direction | Elm type | JavaScript |
+|------|-----------|----------|------------|
+%s
+
+Untrusted data, like what localStorage holds, crosses as `+"`Value`"+` and is
+decoded in Elm, so a stale or edited entry is logged and ignored instead of
+crashing the app. Set `+"`VITE_API_BASE_URL`"+` in `+"`.env`"+` to change the API URL.
+
+To add a port, declare it in `+"`Main.elm`"+` and use it there: Elm drops ports no
+code uses, and `+"`app.ports`"+` won't have them.
+`, g.scriptName(), strings.Join(flags, "\n"), strings.Join(ports, "\n"))
+}
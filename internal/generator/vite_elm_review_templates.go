@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Templates of "vite-elm --lint": an elm-review configuration in review/,
+// which is an Elm application of its own.
+
+// reviewRules are the modules of the rules ReviewConfig.elm enables: no
+// unused code and no debug code
+var reviewRules = []string{
+	"NoDebug.Log",
+	"NoDebug.TodoOrToString",
+	"NoUnused.CustomTypeConstructorArgs",
+	"NoUnused.CustomTypeConstructors",
+	"NoUnused.Dependencies",
+	"NoUnused.Exports",
+	"NoUnused.Parameters",
+	"NoUnused.Patterns",
+	"NoUnused.Variables",
+}
+
+func (g *ViteElmGenerator) reviewConfigTemplate() string {
+	imports := make([]string, len(reviewRules))
+	rules := make([]string, len(reviewRules))
+	for i, rule := range reviewRules {
+		imports[i] = "import " + rule
+		call := rule + ".rule"
+		if rule == "NoUnused.CustomTypeConstructors" {
+			// Takes the phantom types of the project, which has none
+			call += " []"
+		}
+		rules[i] = call
+	}
+
+	return fmt.Sprintf(`module ReviewConfig exposing (config)
+
+{-| The rules "npm run lint" checks the project against, see
+<https://package.elm-lang.org/packages/jfmengels/elm-review/latest/>
+-}
+
+%s
+import Review.Rule exposing (Rule)
+
+
+config : List Rule
+config =
+    [ %s
+    ]
+`, strings.Join(imports, "\n"), strings.Join(rules, "\n    , "))
+}
+
+func (g *ViteElmGenerator) reviewElmJSONTemplate() string {
+	direct := []string{
+		"elm/core",
+		"elm/json",
+		"elm/project-metadata-utils",
+		"jfmengels/elm-review",
+		"jfmengels/elm-review-debug",
+		"jfmengels/elm-review-unused",
+		"stil4m/elm-syntax",
+	}
+	// The dependencies of elm-review and elm-syntax
+	indirect := []string{
+		"elm-explorations/test",
+		"elm/bytes",
+		"elm/html",
+		"elm/parser",
+		"elm/random",
+		"elm/regex",
+		"elm/time",
+		"elm/virtual-dom",
+		"miniBill/elm-unicode",
+		"pzp1997/assoc-list",
+		"rtfeldman/elm-hex",
+		"stil4m/structured-writer",
+	}
+
+	return fmt.Sprintf(`{
+    "type": "application",
+    "source-directories": [
+        "src"
+    ],
+    "elm-version": "%s",
+    "dependencies": {
+        "direct": {
+%s
+        },
+        "indirect": {
+%s
+        }
+    },
+    "test-dependencies": {
+        "direct": {},
+        "indirect": {}
+    }
+}
+`, g.versions.elmTool("elm"),
+		jsonEntries(direct, g.versions.elmPackage, "            "),
+		jsonEntries(indirect, g.versions.elmPackage, "            "))
+}
+
+func (g *ViteElmGenerator) reviewReadmeTemplate() string {
+	return fmt.Sprintf(`
+## Linting
+
+`+"```bash"+`
+npm run lint
+`+"```"+`
+
+runs [elm-review](https://github.com/jfmengels/elm-review) with the rules in
+`+"`review/src/ReviewConfig.elm`"+`: no unused code (%s) and no
+`+"`Debug.log`"+`, `+"`Debug.todo`"+` or `+"`Debug.toString`"+`. `+"`npx elm-review --fix`"+` removes
+most unused code for you. `+"`review/`"+` is an Elm application of its own: add
+rule packages to it with `+"`cd review && npx elm-json install <package>`"+`.
+`, "`NoUnused.*`")
+}
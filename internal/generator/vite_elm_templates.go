@@ -1,31 +1,69 @@
 package generator
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 func (g *ViteElmGenerator) packageJSONTemplate(projectName string) string {
+	build, scripts := "vite build", ""
+	devDependencies := []string{"@tailwindcss/vite", "elm-tooling", "tailwindcss", "vite", "vite-plugin-elm-watch"}
+	if g.ts {
+		build = "tsc && vite build"
+		devDependencies = append(devDependencies, "typescript")
+	}
+	if g.lint {
+		scripts += `
+    "lint": "elm-review",`
+		devDependencies = append(devDependencies, "elm-review")
+	}
+	slices.Sort(devDependencies)
+
 	return fmt.Sprintf(`{
   "name": "%s",
   "version": "1.0.0",
   "type": "module",
   "scripts": {
     "dev": "vite",
-    "build": "vite build",
-    "test": "elm-test",
+    "build": "%s",
+    "test": "elm-test-rs",%s
     "postinstall": "elm-tooling install"
   },
   "devDependencies": {
-    "@tailwindcss/vite": "^4.1.16",
-    "elm-tooling": "^1.16.0",
-    "tailwindcss": "^4.1.16",
-    "vite": "^7.1.12",
-    "vite-plugin-elm-watch": "^1.4.3"
+%s
+  },
+  "engines": {
+    "node": ">=%s"
   }
 }
-`, projectName)
+`, projectName, build, scripts, g.npmDependencies(devDependencies...), g.versions.runtime("node"))
+}
+
+// npmDependencies renders package.json dependency entries with their
+// versions from the catalog
+func (g *ViteElmGenerator) npmDependencies(names ...string) string {
+	return jsonEntries(names, g.versions.npm, "    ")
 }
 
 func (g *ViteElmGenerator) viteConfigTemplate() string {
-	return `import { defineConfig } from 'vite'
+	options := ""
+	if g.app {
+		options += `,
+  // Serve index.html for every path without a file, so routes like
+  // /counter load the app on a reload too (dev server and vite preview)
+  appType: 'spa'`
+	}
+	if g.apiProxy != "" {
+		options += fmt.Sprintf(`,
+  server: {
+    proxy: {
+      '/api': '%s'
+    }
+  }`, g.apiProxy)
+	}
+
+	return fmt.Sprintf(`import { defineConfig } from 'vite'
 import tailwindcss from '@tailwindcss/vite'
 import elmWatch from 'vite-plugin-elm-watch'
 
@@ -33,12 +71,19 @@ export default defineConfig({
   plugins: [
     tailwindcss(),
     elmWatch()
-  ]
+  ]%s
 })
-`
+`, options)
 }
 
 func (g *ViteElmGenerator) indexHTMLTemplate(projectName string) string {
+	// Browser.application renders into <body> itself
+	mount := `  <div id="app"></div>
+`
+	if g.app {
+		mount = ""
+	}
+
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -47,14 +92,22 @@ func (g *ViteElmGenerator) indexHTMLTemplate(projectName string) string {
   <title>%s</title>
 </head>
 <body>
-  <div id="app"></div>
-  <script type="module" src="/src/main.js"></script>
+%s  <script type="module" src="/src/%s"></script>
 </body>
 </html>
-`, projectName)
+`, projectName, mount, g.scriptName())
 }
 
 func (g *ViteElmGenerator) mainJsTemplate() string {
+	if g.app {
+		return `import './style.css'
+import Main from './Main.elm'
+
+// Browser.application takes over the whole <body>
+Main.init()
+`
+	}
+
 	return `import './style.css'
 import Main from './Main.elm'
 
@@ -64,26 +117,35 @@ Main.init({
 `
 }
 
-func (g *ViteElmGenerator) styleCSSTemplate() string {
-	return `@import "tailwindcss";
-
-body {
-  margin: 0;
-  font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', 'Roboto', 'Oxygen',
-    'Ubuntu', 'Cantarell', 'Fira Sans', 'Droid Sans', 'Helvetica Neue',
-    sans-serif;
-  -webkit-font-smoothing: antialiased;
-  -moz-osx-font-smoothing: grayscale;
-}
-`
-}
-
 func (g *ViteElmGenerator) mainElmTemplate() string {
-	return `module Main exposing (main)
+	styles := g.counterStyles()
+
+	// The dark mode toggle keeps its state in the model and sets the dark
+	// class on the root element
+	var attributes, field, init, msg, update, root, toggle, toggleView string
+	if g.darkMode {
+		attributes = ", classList"
+		field = `
+    , dark : Bool`
+		init = `
+    , dark = False`
+		msg = `
+    | ToggleDarkMode`
+		update = `
+
+        ToggleDarkMode ->
+            { model | dark = not model.dark }`
+		root = `classList [ ( "dark", model.dark ) ], `
+		toggle = `
+            , darkModeToggle model.dark`
+		toggleView = darkModeToggleElm("mt-6 w-full text-sm text-muted hover:text-ink")
+	}
+
+	return fmt.Sprintf(`module Main exposing (Msg(..), init, main, update)
 
 import Browser
 import Html exposing (Html, div, h1, text, button)
-import Html.Attributes exposing (class)
+import Html.Attributes exposing (class%[1]s)
 import Html.Events exposing (onClick)
 
 
@@ -103,13 +165,13 @@ main =
 
 
 type alias Model =
-    { count : Int
+    { count : Int%[2]s
     }
 
 
 init : Model
 init =
-    { count = 0
+    { count = 0%[3]s
     }
 
 
@@ -118,7 +180,7 @@ init =
 
 type Msg
     = Increment
-    | Decrement
+    | Decrement%[4]s
 
 
 update : Msg -> Model -> Model
@@ -128,7 +190,7 @@ update msg model =
             { model | count = model.count + 1 }
 
         Decrement ->
-            { model | count = model.count - 1 }
+            { model | count = model.count - 1 }%[5]s
 
 
 -- VIEW
@@ -136,66 +198,96 @@ update msg model =
 
 view : Model -> Html Msg
 view model =
-    div [ class "min-h-screen bg-gray-100 flex items-center justify-center" ]
-        [ div [ class "bg-white p-8 rounded-lg shadow-lg" ]
-            [ h1 [ class "text-3xl font-bold text-center mb-6 text-gray-800" ]
+    div [ %[6]sclass "min-h-screen bg-canvas text-ink flex items-center justify-center" ]
+        [ div [ class "%[7]s" ]
+            [ h1 [ class "text-3xl font-bold text-center mb-6" ]
                 [ text "Elm + Vite + Tailwind" ]
             , div [ class "flex items-center justify-center gap-4" ]
                 [ button
                     [ onClick Decrement
-                    , class "px-4 py-2 bg-red-500 text-white rounded hover:bg-red-600"
+                    , class "%[8]s"
                     ]
                     [ text "-" ]
                 , div [ class "text-2xl font-mono w-16 text-center" ]
                     [ text (String.fromInt model.count) ]
                 , button
                     [ onClick Increment
-                    , class "px-4 py-2 bg-green-500 text-white rounded hover:bg-green-600"
+                    , class "%[9]s"
                     ]
                     [ text "+" ]
-                ]
+                ]%[10]s
             ]
         ]
-`
+%[11]s`, attributes, field, init, msg, update, root, styles.card, styles.decrement, styles.increment, toggle, toggleView)
 }
 
 func (g *ViteElmGenerator) elmJSONTemplate() string {
-	return `{
+	direct := []string{"elm/browser", "elm/core", "elm/html"}
+	if g.app {
+		// Routing parses URLs with elm/url
+		direct = append(direct, "elm/url")
+	}
+	if g.interop {
+		// Flags and ports carry JSON values
+		direct = append(direct, "elm/json")
+	}
+	slices.Sort(direct)
+
+	var indirect []string
+	for _, pkg := range []string{"elm/json", "elm/time", "elm/url", "elm/virtual-dom"} {
+		if !slices.Contains(direct, pkg) {
+			indirect = append(indirect, pkg)
+		}
+	}
+
+	return fmt.Sprintf(`{
     "type": "application",
     "source-directories": [
         "src"
     ],
-    "elm-version": "0.19.1",
+    "elm-version": "%s",
     "dependencies": {
         "direct": {
-            "elm/browser": "1.0.2",
-            "elm/core": "1.0.5",
-            "elm/html": "1.0.0"
+%s
         },
         "indirect": {
-            "elm/json": "1.1.3",
-            "elm/time": "1.0.0",
-            "elm/url": "1.0.0",
-            "elm/virtual-dom": "1.0.3"
+%s
         }
     },
     "test-dependencies": {
-        "direct": {},
-        "indirect": {}
+        "direct": {
+%s
+        },
+        "indirect": {
+%s
+        }
     }
 }
-`
+`, g.versions.elmTool("elm"),
+		jsonEntries(direct, g.versions.elmPackage, "            "),
+		jsonEntries(indirect, g.versions.elmPackage, "            "),
+		jsonEntries([]string{"elm-explorations/test"}, g.versions.elmPackage, "            "),
+		// elm-explorations/test's dependencies the application doesn't have
+		jsonEntries([]string{"elm/bytes", "elm/random"}, g.versions.elmPackage, "            "))
 }
 
 func (g *ViteElmGenerator) elmToolingJSONTemplate() string {
-	return `{
+	return fmt.Sprintf(`{
   "tools": {
-    "elm": "0.19.1",
-    "elm-format": "0.8.7",
-    "elm-json": "0.2.13"
+%s
   }
 }
-`
+`, jsonEntries([]string{"elm", "elm-format", "elm-json", "elm-test-rs"}, g.versions.elmTool, "    "))
+}
+
+// jsonEntries renders "name": "version" lines of a JSON object, separated
+// by commas, with the versions looked up by version
+func jsonEntries(names []string, version func(name string) string, indent string) string {
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%s%q: %q", indent, name, version(name))
+	}
+	return strings.Join(lines, ",\n")
 }
 
 func (g *ViteElmGenerator) gitignoreTemplate() string {
@@ -209,9 +301,11 @@ dist/
 # Elm
 .elm-spa/
 
-# IDE
+# IDE (shared VS Code settings are kept)
 .idea/
-.vscode/
+.vscode/*
+!.vscode/settings.json
+!.vscode/extensions.json
 *.swp
 *.swo
 *~
@@ -258,6 +352,10 @@ npm run build
 npm test
 `+"```"+`
 
+runs the unit and fuzz tests in `+"`tests/`"+` with
+[elm-test-rs](https://github.com/mpizenberg/elm-test-rs), which elm-tooling
+installs. Test modules expose a `+"`Test`"+`, usually named `+"`suite`"+`.
+%s
 ## Stack
 
 - [Vite](https://vitejs.dev/) - Build tool
@@ -269,5 +367,48 @@ npm test
 ## License
 
 MIT
-`, projectName)
+`, projectName, g.readmeSections())
+}
+
+// readmeSections documents the options the project was generated with
+func (g *ViteElmGenerator) readmeSections() string {
+	var sections []string
+	if g.app {
+		sections = append(sections, g.appReadmeTemplate())
+	}
+	if g.interop {
+		sections = append(sections, g.interopReadmeTemplate())
+	}
+	if g.lint {
+		sections = append(sections, g.reviewReadmeTemplate())
+	}
+	if g.ts {
+		sections = append(sections, g.tsReadmeTemplate())
+	}
+	sections = append(sections, g.themeReadmeTemplate())
+	return strings.Join(sections, "")
+}
+
+// elmLintCmd fails on Elm files elm-format would change
+const elmLintCmd = "npx elm-format --validate src tests"
+
+func (g *ViteElmGenerator) tasks() []task {
+	lint := task{name: "lint", desc: "Check Elm formatting", aliases: []string{"l"}, cmds: []string{elmLintCmd}}
+	if g.lint {
+		lint.desc = "Check Elm formatting and run elm-review"
+		lint.cmds = append(lint.cmds, "npm run lint")
+	}
+	build := []string{"npx vite build"}
+	if g.ts {
+		build = append([]string{"npx tsc"}, build...)
+	}
+
+	return []task{
+		{name: "install", desc: "Install dependencies and Elm tools", cmds: []string{"npm install"}},
+		{name: "dev", desc: "Run the Vite dev server", aliases: []string{"d"}, cmds: []string{"npm run dev"}},
+		{name: "test", desc: "Run the Elm tests", aliases: []string{"t"}, cmds: []string{"npm test"}},
+		{name: "build", desc: "Build with vite build into dist/", aliases: []string{"b"}, cmds: build},
+		lint,
+		{name: "clean", desc: "Remove build output", cmds: []string{"rm -rf dist elm-stuff"}},
+	}
 }
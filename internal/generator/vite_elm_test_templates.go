@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Templates of the elm-test suite in tests/: unit and fuzz tests of the
+// counter's update, for whichever module the counter lives in.
+
+// counterTestPath returns the path of the counter tests, relative to the
+// project root
+func (g *ViteElmGenerator) counterTestPath() string {
+	if g.app {
+		return "tests/Page/CounterTest.elm"
+	}
+	return "tests/MainTest.elm"
+}
+
+func (g *ViteElmGenerator) counterTestTemplate() string {
+	if g.interop {
+		return g.interopMainTestTemplate()
+	}
+
+	module, tested, darkMode := "MainTest", "Main", ""
+	if g.app {
+		module, tested = "Page.CounterTest", "Page.Counter"
+	} else if g.darkMode {
+		darkMode = darkModeTestElm("init", "        ")
+	}
+
+	return fmt.Sprintf(`module %s exposing (suite)
+
+import Expect
+import Fuzz exposing (Fuzzer)
+import %s exposing (Msg(..), init, update)
+import Test exposing (Test, describe, fuzz, fuzz2, test)
+
+
+suite : Test
+suite =
+    describe "update"
+        [ test "Increment adds one" <|
+            \_ ->
+                update Increment init
+                    |> .count
+                    |> Expect.equal 1
+        , test "Decrement subtracts one" <|
+            \_ ->
+                update Decrement init
+                    |> .count
+                    |> Expect.equal -1
+        , fuzz Fuzz.int "Decrement undoes Increment" <|
+            \count ->
+                { init | count = count }
+                    |> update Increment
+                    |> update Decrement
+                    |> Expect.equal { init | count = count }
+        , fuzz2 Fuzz.int (Fuzz.list msgFuzzer) "counts the increments minus the decrements" <|
+            \count msgs ->
+                List.foldl update { init | count = count } msgs
+                    |> .count
+                    |> Expect.equal (count + occurrences Increment msgs - occurrences Decrement msgs)%[3]s
+        ]
+
+
+msgFuzzer : Fuzzer Msg
+msgFuzzer =
+    Fuzz.oneOfValues [ Increment, Decrement ]
+
+
+occurrences : Msg -> List Msg -> Int
+occurrences msg msgs =
+    List.length (List.filter ((==) msg) msgs)
+`, module, tested, darkMode)
+}
+
+// interopMainTestTemplate tests the interop counter, whose update also
+// returns the commands for its ports
+func (g *ViteElmGenerator) interopMainTestTemplate() string {
+	darkMode := ""
+	if g.darkMode {
+		darkMode = darkModeTestElm("model", "            ")
+	}
+
+	return fmt.Sprintf(`module MainTest exposing (suite)
+
+import Expect
+import Fuzz exposing (Fuzzer)
+import Json.Encode as Encode
+import Main exposing (Model, Msg(..), init)
+import Test exposing (Test, describe, fuzz, fuzz2, test)
+
+
+suite : Test
+suite =
+    describe "Main"
+        [ describe "init"
+            [ test "starts at zero on the first visit" <|
+                \_ ->
+                    start Encode.null
+                        |> .count
+                        |> Expect.equal 0
+            , fuzz Fuzz.int "restores the saved count" <|
+                \count ->
+                    start (Encode.object [ ( "count", Encode.int count ) ])
+                        |> .count
+                        |> Expect.equal count
+            , test "ignores an invalid saved state" <|
+                \_ ->
+                    start (Encode.string "not a state")
+                        |> .count
+                        |> Expect.equal 0
+            ]
+        , describe "update"
+            [ test "Increment adds one" <|
+                \_ ->
+                    update Increment model
+                        |> .count
+                        |> Expect.equal 1
+            , fuzz Fuzz.int "Decrement undoes Increment" <|
+                \count ->
+                    { model | count = count }
+                        |> update Increment
+                        |> update Decrement
+                        |> Expect.equal { model | count = count }
+            , fuzz2 Fuzz.int (Fuzz.list msgFuzzer) "counts the increments minus the decrements" <|
+                \count msgs ->
+                    List.foldl update { model | count = count } msgs
+                        |> .count
+                        |> Expect.equal (count + occurrences Increment msgs - occurrences Decrement msgs)
+            , fuzz Fuzz.int "takes the count saved by another tab" <|
+                \count ->
+                    update (StateChanged (Encode.object [ ( "count", Encode.int count ) ])) model
+                        |> .count
+                        |> Expect.equal count%s
+            ]
+        ]
+
+
+{-| The model of a first visit
+-}
+model : Model
+model =
+    start Encode.null
+
+
+{-| The model init starts with, given the saved state
+-}
+start : Encode.Value -> Model
+start savedState =
+    Tuple.first (init { apiBaseUrl = "/api", savedState = savedState })
+
+
+{-| Main.update without the commands, which tests can't inspect
+-}
+update : Msg -> Model -> Model
+update msg m =
+    Tuple.first (Main.update msg m)
+
+
+msgFuzzer : Fuzzer Msg
+msgFuzzer =
+    Fuzz.oneOfValues [ Increment, Decrement ]
+
+
+occurrences : Msg -> List Msg -> Int
+occurrences msg msgs =
+    List.length (List.filter ((==) msg) msgs)
+`, darkMode)
+}
+
+// darkModeTestElm is a test of the dark mode toggle, starting from the
+// model named start, for a list of tests indented by indent
+func darkModeTestElm(start, indent string) string {
+	test := fmt.Sprintf(`
+, test "ToggleDarkMode switches dark mode on and off" <|
+    \_ ->
+        %[1]s
+            |> update ToggleDarkMode
+            |> Expect.all
+                [ .dark >> Expect.equal True
+                , update ToggleDarkMode >> Expect.equal %[1]s
+                ]`, start)
+	return strings.ReplaceAll(test, "\n", "\n"+indent)
+}
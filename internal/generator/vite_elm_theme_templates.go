@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Templates of the Tailwind theme: design tokens in style.css that the Elm
+// views use, with "vite-elm --dark-mode" and "--components" on top.
+
+// themeToken is a CSS variable of the @theme block. Tailwind generates
+// utilities from it, like bg-danger for --color-danger.
+type themeToken struct {
+	name  string
+	value string
+	// dark is the value under the dark class, empty to keep value
+	dark string
+}
+
+// themeTokens are the design tokens of style.css, by group. The colours
+// are Tailwind's palette, so the defaults look like the stock classes.
+var themeTokens = []struct {
+	comment string
+	tokens  []themeToken
+}{
+	{"Brand and action colours", []themeToken{
+		{name: "--color-brand", value: "oklch(0.546 0.245 262.881)", dark: "oklch(0.707 0.165 254.624)"},
+		{name: "--color-brand-hover", value: "oklch(0.488 0.243 264.376)", dark: "oklch(0.809 0.105 251.813)"},
+		{name: "--color-danger", value: "oklch(0.637 0.237 25.331)"},
+		{name: "--color-danger-hover", value: "oklch(0.577 0.245 27.325)"},
+		{name: "--color-success", value: "oklch(0.723 0.219 149.579)"},
+		{name: "--color-success-hover", value: "oklch(0.627 0.194 149.214)"},
+	}},
+	{"Surfaces and text", []themeToken{
+		{name: "--color-canvas", value: "oklch(0.967 0.003 264.542)", dark: "oklch(0.21 0.034 264.665)"},
+		{name: "--color-surface", value: "oklch(1 0 0)", dark: "oklch(0.278 0.033 256.848)"},
+		{name: "--color-ink", value: "oklch(0.278 0.033 256.848)", dark: "oklch(0.967 0.003 264.542)"},
+		{name: "--color-muted", value: "oklch(0.551 0.027 264.364)", dark: "oklch(0.707 0.022 261.325)"},
+		{name: "--color-line", value: "oklch(0.872 0.01 258.338)", dark: "oklch(0.446 0.03 256.802)"},
+	}},
+	{"Fonts", []themeToken{
+		{name: "--font-sans", value: `-apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", sans-serif`},
+		{name: "--font-mono", value: `ui-monospace, SFMono-Regular, Menlo, Consolas, monospace`},
+	}},
+	{"Corner radius", []themeToken{
+		{name: "--radius-card", value: "0.5rem"},
+		{name: "--radius-control", value: "0.25rem"},
+	}},
+}
+
+func (g *ViteElmGenerator) styleCSSTemplate() string {
+	var theme, dark []string
+	for i, group := range themeTokens {
+		if i > 0 {
+			theme = append(theme, "")
+		}
+		theme = append(theme, "  /* "+group.comment+" */")
+		for _, t := range group.tokens {
+			theme = append(theme, fmt.Sprintf("  %s: %s;", t.name, t.value))
+			if t.dark != "" {
+				dark = append(dark, fmt.Sprintf("    %s: %s;", t.name, t.dark))
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(`@import "tailwindcss";
+`)
+	if g.darkMode {
+		b.WriteString(`
+/* dark: utilities apply inside an element with the dark class, which the
+   Elm app toggles, instead of following prefers-color-scheme */
+@custom-variant dark (&:where(.dark, .dark *));
+`)
+	}
+
+	fmt.Fprintf(&b, `
+/* Design tokens. Tailwind generates utilities from them, like bg-brand,
+   font-sans or rounded-card, and they are CSS variables for your own CSS. */
+@theme {
+%s
+}
+
+@layer base {
+  body {
+    -webkit-font-smoothing: antialiased;
+    -moz-osx-font-smoothing: grayscale;
+  }
+`, strings.Join(theme, "\n"))
+	if g.darkMode {
+		fmt.Fprintf(&b, `
+  /* The tokens in dark mode: utilities like bg-surface follow them */
+  .dark {
+%s
+  }
+`, strings.Join(dark, "\n"))
+	}
+	b.WriteString("}\n")
+
+	if g.components {
+		b.WriteString(`
+/* Components combining the tokens, for elements repeated across views */
+@layer components {
+  .card {
+    @apply bg-surface p-8 rounded-card shadow-lg;
+  }
+
+  .btn {
+    @apply px-4 py-2 text-white rounded-control;
+  }
+
+  .btn-danger {
+    @apply bg-danger hover:bg-danger-hover;
+  }
+
+  .btn-success {
+    @apply bg-success hover:bg-success-hover;
+  }
+}
+`)
+	}
+
+	return b.String()
+}
+
+// counterStyles are the classes of the counter card in the Elm views
+type counterStyles struct {
+	card, decrement, increment string
+}
+
+func (g *ViteElmGenerator) counterStyles() counterStyles {
+	if g.components {
+		return counterStyles{
+			card:      "card",
+			decrement: "btn btn-danger",
+			increment: "btn btn-success",
+		}
+	}
+	return counterStyles{
+		card:      "bg-surface p-8 rounded-card shadow-lg",
+		decrement: "px-4 py-2 bg-danger text-white rounded-control hover:bg-danger-hover",
+		increment: "px-4 py-2 bg-success text-white rounded-control hover:bg-success-hover",
+	}
+}
+
+// darkModeToggleElm is the Elm function rendering the dark mode toggle,
+// with the given classes
+func darkModeToggleElm(classes string) string {
+	return fmt.Sprintf(`
+
+darkModeToggle : Bool -> Html Msg
+darkModeToggle dark =
+    button
+        [ onClick ToggleDarkMode
+        , class "%s"
+        ]
+        [ text
+            (if dark then
+                "Light mode"
+
+             else
+                "Dark mode"
+            )
+        ]
+`, classes)
+}
+
+func (g *ViteElmGenerator) themeReadmeTemplate() string {
+	var extras []string
+	if g.darkMode {
+		extras = append(extras, `
+Dark mode uses the `+"`dark`"+` class instead of `+"`prefers-color-scheme`"+`: the Elm
+app sets it on its root element with the toggle, `+"`.dark`"+` switches the colour
+tokens, and `+"`dark:`"+` utilities apply inside it.
+`)
+	}
+	if g.components {
+		extras = append(extras, fmt.Sprintf(`
+The components layer of `+"`style.css`"+` combines the tokens into %s, %s, %s and
+%s classes. Utilities still override them, like `+"`card p-4`"+`.
+`, "`card`", "`btn`", "`btn-danger`", "`btn-success`"))
+	}
+
+	return fmt.Sprintf(`
+## Design tokens
+
+`+"`src/style.css`"+` defines the design tokens in Tailwind's `+"`@theme`"+`: brand colours,
+surfaces and text colours, fonts and corner radii. The views use the classes
+Tailwind generates from them, like `+"`bg-danger`"+` or `+"`rounded-card`"+`, rather than
+raw palette classes, so changing a token restyles the app.
+%s`, strings.Join(extras, ""))
+}
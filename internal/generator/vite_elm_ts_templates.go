@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Templates of "vite-elm --ts": main.ts instead of main.js, type-checked
+// by tsc against a declaration of the Elm program.
+
+// scriptName is the name of the entry script in src/
+func (g *ViteElmGenerator) scriptName() string {
+	if g.ts {
+		return "main.ts"
+	}
+	return "main.js"
+}
+
+// viteConfigName is the name of the Vite config file
+func (g *ViteElmGenerator) viteConfigName() string {
+	if g.ts {
+		return "vite.config.ts"
+	}
+	return "vite.config.js"
+}
+
+// elmProgram returns the flags and ports of the generated Main.elm
+func (g *ViteElmGenerator) elmProgram() ([]elmFlag, []elmPort) {
+	if g.interop {
+		return interopFlags, interopPorts
+	}
+	return nil, nil
+}
+
+func (g *ViteElmGenerator) tsconfigTemplate() string {
+	return `{
+  "compilerOptions": {
+    "target": "ES2022",
+    "module": "ESNext",
+    "moduleResolution": "bundler",
+    "lib": ["ES2022", "DOM", "DOM.Iterable"],
+    "types": ["vite/client"],
+    "strict": true,
+    "noEmit": true,
+    "isolatedModules": true,
+    "skipLibCheck": true,
+    "noUnusedLocals": true,
+    "noUnusedParameters": true
+  },
+  "include": ["src"]
+}
+`
+}
+
+// elmDeclarationTemplate declares the module vite-plugin-elm-watch makes of
+// Main.elm, with the flags and ports it has
+func (g *ViteElmGenerator) elmDeclarationTemplate() string {
+	flags, ports := g.elmProgram()
+
+	var b strings.Builder
+	b.WriteString(`// Types of src/Main.elm as main.ts imports it. Elm doesn't generate them:
+// keep them in sync with the flags and ports of Main.elm.
+`)
+
+	if len(flags) > 0 {
+		b.WriteString("\n/** The Flags record of Main.elm */\nexport interface Flags {\n")
+		for _, f := range flags {
+			fmt.Fprintf(&b, "  /** %s */\n  %s: %s\n", f.doc, f.name, f.tsType)
+		}
+		b.WriteString("}\n")
+	}
+
+	if len(ports) > 0 {
+		b.WriteString("\n/** The ports of Main.elm */\nexport interface Ports {\n")
+		for _, p := range ports {
+			if p.incoming {
+				fmt.Fprintf(&b, "  /** To Elm: %s */\n  %s: { send(value: %s): void }\n", p.doc, p.name, p.tsType)
+				continue
+			}
+			fmt.Fprintf(&b, `  /** From Elm: %[1]s */
+  %[2]s: {
+    subscribe(callback: (value: %[3]s) => void): void
+    unsubscribe(callback: (value: %[3]s) => void): void
+  }
+`, p.doc, p.name, p.tsType)
+		}
+		b.WriteString("}\n")
+	}
+
+	// Browser.application takes over the whole <body>, the others render
+	// into a node
+	var options []string
+	if !g.app {
+		options = append(options, "node: HTMLElement | null")
+	}
+	if len(flags) > 0 {
+		options = append(options, "flags: Flags")
+	}
+	init := "init(): App"
+	if len(options) > 0 {
+		init = fmt.Sprintf("init(options: { %s }): App", strings.Join(options, "; "))
+	}
+
+	// Elm only sets app.ports when the program has ports
+	app := "export interface App {}\n"
+	if len(ports) > 0 {
+		app = "export interface App {\n  ports: Ports\n}\n"
+	}
+
+	fmt.Fprintf(&b, `
+/** A running Elm program */
+%s
+declare const Main: {
+  %s
+}
+
+export default Main
+`, app, init)
+
+	return b.String()
+}
+
+func (g *ViteElmGenerator) tsReadmeTemplate() string {
+	declared := "the type of `Main.init`"
+	if flags, ports := g.elmProgram(); len(flags) > 0 || len(ports) > 0 {
+		declared = "the types of `Main.init`, of the `Flags` it takes and of `app.ports`"
+	}
+
+	return fmt.Sprintf(`
+## TypeScript
+
+`+"`src/main.ts`"+` starts the Elm app. `+"`npm run build`"+` type-checks it with `+"`tsc`"+`
+before bundling, against the declarations in `+"`src/Main.elm.d.ts`"+`:
+%s. Elm doesn't generate them, so update
+`+"`Main.elm.d.ts`"+` whenever you change the flags or ports of `+"`Main.elm`"+`.
+`, declared)
+}